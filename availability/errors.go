@@ -0,0 +1,21 @@
+package availability
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidDateRange = status.Error(codes.InvalidArgument, "dates must be YYYY-MM-DD with end date not before start date and at most 31 days apart")
+	ErrInvalidDuration  = status.Error(codes.InvalidArgument, "duration must be between 5 minutes and 24 hours")
+	ErrInvalidPartySize = status.Error(codes.InvalidArgument, "party size must be at least 1")
+	ErrInvalidStep      = status.Error(codes.InvalidArgument, "step must be between 5 minutes and 24 hours")
+	ErrInternal         = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[availability] %v", err)
+	return ErrInternal
+}
@@ -0,0 +1,35 @@
+package availability
+
+import (
+	"time"
+)
+
+// Search describe the slots the customer is looking for
+type Search struct {
+	VenueID int
+	// ResourceIDs limit the search to some resources of the venue
+	ResourceIDs []int
+	// StartDate and EndDate are inclusive YYYY-MM-DD dates in the venue time zone
+	StartDate string
+	EndDate   string
+	Duration  time.Duration
+	PartySize int
+	// Step is the spacing of slot start times on the venue clock, e.g. every 15 minutes
+	Step  time.Duration
+	Limit int
+}
+
+// Slot is a free interval on a resource which can be booked
+type Slot struct {
+	ResourceID int       `json:"r"`
+	Start      time.Time `json:"s"`
+	End        time.Time `json:"e"`
+}
+
+type Result struct {
+	// Timezone is IANA time zone of the venue
+	Timezone string `json:"tz"`
+	Slots    []Slot `json:"slots"`
+	// Truncated is set when there are more slots than the limit
+	Truncated bool `json:"truncated"`
+}
@@ -0,0 +1,66 @@
+package availability
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+// redis keys
+const (
+	// keySearch cache Result by hash of the search and the resource versions
+	keySearch = "availability:search:%s"
+)
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	GetCachedResult(ctx context.Context, key string) (Result, error)
+	CacheResult(ctx context.Context, key string, result Result, ttl time.Duration) error
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+// GetCachedResult return redis.ErrNil if the search is not cached
+func (r *repository) GetCachedResult(ctx context.Context, key string) (Result, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return Result{}, err
+	}
+	defer conn.Close()
+
+	value, err := redis.Bytes(conn.Do("GET", fmt.Sprintf(keySearch, key)))
+	if err != nil {
+		return Result{}, err
+	}
+	var result Result
+	err = json.Unmarshal(value, &result)
+	return result, err
+}
+
+func (r *repository) CacheResult(ctx context.Context, key string, result Result, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	value, err := json.Marshal(result)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("SET", fmt.Sprintf(keySearch, key), value, "PX", ttl.Milliseconds())
+	return err
+}
@@ -0,0 +1,263 @@
+package availability
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/booking-man-be/booking"
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/schedule"
+	"github.com/booking-man-be/venue"
+	"github.com/gomodule/redigo/redis"
+)
+
+const (
+	// maxSearchDays limit the dates of a search
+	maxSearchDays = 31
+	minDuration   = 5 * time.Minute
+	maxDuration   = 24 * time.Hour
+	defaultStep   = 15 * time.Minute
+	defaultLimit  = 500
+	maxLimit      = 5000
+	// cacheTTL bound how long a search is kept, changes are visible at once
+	// through the schedule and resource versions and the cached search expire
+	// with the first hold it contain
+	cacheTTL = time.Minute
+)
+
+type service struct {
+	repo      Repository
+	venues    venue.Service
+	resources resource.Service
+	schedules schedule.Service
+	bookings  booking.Service
+}
+
+type Service interface {
+	SearchAvailability(ctx context.Context, search Search) (Result, error)
+}
+
+func NewService(repo Repository, venues venue.Service, resources resource.Service, schedules schedule.Service, bookings booking.Service) Service {
+	return &service{
+		repo:      repo,
+		venues:    venues,
+		resources: resources,
+		schedules: schedules,
+		bookings:  bookings,
+	}
+}
+
+// SearchAvailability return free slots of the resources which fit the party
+// size, after removing closures, bookings and the buffers around them
+func (s *service) SearchAvailability(ctx context.Context, search Search) (Result, error) {
+	if err := normalizeSearch(&search); err != nil {
+		return Result{}, err
+	}
+	v, err := s.venues.GetVenue(ctx, search.VenueID)
+	if err != nil {
+		return Result{}, err
+	}
+	if v.ArchivedAt != nil {
+		return Result{}, venue.ErrVenueArchived
+	}
+	loc, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return Result{}, internalError(err)
+	}
+	start, end, err := parseDateRange(loc, search.StartDate, search.EndDate)
+	if err != nil {
+		return Result{}, err
+	}
+
+	resources, err := s.resources.ListActiveResources(ctx, v.ID, search.ResourceIDs)
+	if err != nil {
+		return Result{}, err
+	}
+	var fitting []resource.Resource
+	var resourceIDs []int
+	for _, r := range resources {
+		if r.Capacity >= search.PartySize {
+			fitting = append(fitting, r)
+			resourceIDs = append(resourceIDs, r.ID)
+		}
+	}
+	now := time.Now()
+	if len(fitting) == 0 {
+		return Result{Timezone: v.Timezone}, nil
+	}
+
+	scheduleVersion, err := s.venues.ScheduleVersion(ctx, v.ID)
+	if err != nil {
+		return Result{}, err
+	}
+	versions, err := s.bookings.ResourceVersions(ctx, resourceIDs)
+	if err != nil {
+		return Result{}, err
+	}
+	key := cacheKey(search, resourceIDs, scheduleVersion, versions)
+	result, err := s.repo.GetCachedResult(ctx, key)
+	if err == nil {
+		return dropPastSlots(result, now), nil
+	}
+	if err != redis.ErrNil {
+		logger.Errorf("[availability] failed to read cached search, %v", err)
+	}
+
+	result, until, err := s.search(ctx, v, fitting, search, start, end)
+	if err != nil {
+		return Result{}, err
+	}
+	ttl := cacheTTL
+	if !until.IsZero() && until.Sub(now) < ttl {
+		ttl = until.Sub(now)
+	}
+	if ttl > 0 {
+		if err := s.repo.CacheResult(ctx, key, result, ttl); err != nil {
+			logger.Errorf("[availability] failed to cache search, %v", err)
+		}
+	}
+	return dropPastSlots(result, now), nil
+}
+
+// search compute the result and the time it stay valid until, zero when only
+// a change of the versions can make it stale
+func (s *service) search(ctx context.Context, v venue.Venue, resources []resource.Resource, search Search, start, end time.Time) (Result, time.Time, error) {
+	schedules, err := s.schedules.GetResourceSchedules(ctx, v, resources, start, end)
+	if err != nil {
+		return Result{}, time.Time{}, err
+	}
+
+	var maxBuffer time.Duration
+	resourceIDs := make([]int, 0, len(resources))
+	for _, r := range resources {
+		resourceIDs = append(resourceIDs, r.ID)
+		if r.Buffer() > maxBuffer {
+			maxBuffer = r.Buffer()
+		}
+	}
+	until := end.AddDate(0, 0, 1)
+	busy, validUntil, err := s.bookings.ListBusyIntervals(ctx, resourceIDs, start.Add(-maxBuffer), until.Add(maxBuffer))
+	if err != nil {
+		return Result{}, time.Time{}, err
+	}
+
+	// a slot may start now at the earliest, it is rounded to the step by the slot generation
+	notBefore := time.Now()
+	result := Result{Timezone: v.Timezone}
+	for _, r := range resources {
+		blocked := make([]schedule.Interval, 0, len(busy[r.ID]))
+		for _, b := range busy[r.ID] {
+			blocked = append(blocked, schedule.Interval{
+				Start: b.Start.Add(-r.Buffer()),
+				End:   b.End.Add(r.Buffer()),
+			})
+		}
+		free := schedule.Subtract(schedules[r.ID], schedule.Merge(blocked))
+		result.Slots = append(result.Slots, slots(r.ID, free, start.Location(), search, notBefore)...)
+	}
+
+	sort.Slice(result.Slots, func(i, j int) bool {
+		if !result.Slots[i].Start.Equal(result.Slots[j].Start) {
+			return result.Slots[i].Start.Before(result.Slots[j].Start)
+		}
+		return result.Slots[i].ResourceID < result.Slots[j].ResourceID
+	})
+	if len(result.Slots) > search.Limit {
+		result.Slots = result.Slots[:search.Limit]
+		result.Truncated = true
+	}
+	return result, validUntil, nil
+}
+
+// slots cut the free intervals into slots of the search duration starting on the step of the venue clock
+func slots(resourceID int, free []schedule.Interval, loc *time.Location, search Search, notBefore time.Time) []Slot {
+	var result []Slot
+	for _, interval := range free {
+		from := interval.Start
+		if from.Before(notBefore) {
+			from = notBefore
+		}
+		for t := alignToStep(from, loc, search.Step); !t.Add(search.Duration).After(interval.End); t = alignToStep(t.Add(search.Step), loc, search.Step) {
+			result = append(result, Slot{ResourceID: resourceID, Start: t, End: t.Add(search.Duration)})
+		}
+	}
+	return result
+}
+
+// alignToStep round t up to the next multiple of step since midnight of the venue clock,
+// the wall clock is used so slots keep their time of day on daylight saving changes.
+// Rounding may skip the clock over a gap off the step, it is then rounded again
+func alignToStep(t time.Time, loc *time.Location, step time.Duration) time.Time {
+	for {
+		local := t.In(loc)
+		h, m, s := local.Clock()
+		wall := time.Duration(h)*time.Hour + time.Duration(m)*time.Minute +
+			time.Duration(s)*time.Second + time.Duration(local.Nanosecond())
+		rem := wall % step
+		if rem == 0 {
+			return t
+		}
+		t = t.Add(step - rem)
+	}
+}
+
+// dropPastSlots remove slots which started since the result was computed
+func dropPastSlots(result Result, now time.Time) Result {
+	i := 0
+	for i < len(result.Slots) && result.Slots[i].Start.Before(now) {
+		i++
+	}
+	result.Slots = result.Slots[i:]
+	return result
+}
+
+func normalizeSearch(search *Search) error {
+	if search.Duration < minDuration || search.Duration > maxDuration {
+		return ErrInvalidDuration
+	}
+	if search.PartySize < 1 {
+		return ErrInvalidPartySize
+	}
+	if search.Step == 0 {
+		search.Step = defaultStep
+	}
+	if search.Step < minDuration || search.Step > maxDuration {
+		return ErrInvalidStep
+	}
+	if search.Limit < 1 {
+		search.Limit = defaultLimit
+	}
+	if search.Limit > maxLimit {
+		search.Limit = maxLimit
+	}
+	return nil
+}
+
+// cacheKey hash everything the result depend on, resourceIDs must be sorted
+func cacheKey(search Search, resourceIDs []int, scheduleVersion int64, versions []int64) string {
+	sum := sha256.Sum256([]byte(fmt.Sprintf("%d|%v|%d|%v|%s|%s|%d|%d|%d|%d",
+		search.VenueID, resourceIDs, scheduleVersion, versions, search.StartDate, search.EndDate,
+		search.Duration, search.PartySize, search.Step, search.Limit)))
+	return hex.EncodeToString(sum[:])
+}
+
+// parseDateRange parse inclusive dates as midnight in loc
+func parseDateRange(loc *time.Location, startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(schedule.DateLayout, startDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	end, err := time.ParseInLocation(schedule.DateLayout, endDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	if end.Before(start) || end.After(start.AddDate(0, 0, maxSearchDays)) {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, end, nil
+}
@@ -0,0 +1,382 @@
+package availability
+
+import (
+	"context"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/booking-man-be/booking"
+	"github.com/booking-man-be/config"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/schedule"
+	"github.com/booking-man-be/venue"
+	"github.com/gomodule/redigo/redis"
+)
+
+type testVenues struct {
+	venue.Service
+	venue           venue.Venue
+	scheduleVersion int64
+}
+
+func (f testVenues) GetVenue(ctx context.Context, id int) (venue.Venue, error) {
+	return f.venue, nil
+}
+
+func (f testVenues) ScheduleVersion(ctx context.Context, venueID int) (int64, error) {
+	return f.scheduleVersion, nil
+}
+
+type testResources struct {
+	resource.Service
+	resources []resource.Resource
+}
+
+func (f testResources) ListActiveResources(ctx context.Context, venueID int, ids []int) ([]resource.Resource, error) {
+	return f.resources, nil
+}
+
+type testExceptions struct {
+	schedule.Repository
+}
+
+func (testExceptions) ListExceptions(ctx context.Context, venueID int, startDate, endDate string) ([]schedule.Exception, error) {
+	return nil, nil
+}
+
+type testBookings struct {
+	booking.Repository
+	bookings []booking.Booking
+	holds    []booking.Hold
+}
+
+func (f testBookings) ListOccupyingBookings(ctx context.Context, resourceIDs []int, from, to time.Time) ([]booking.Booking, error) {
+	var bookings []booking.Booking
+	for _, b := range f.bookings {
+		if b.StartAt.Before(to) && b.EndAt.After(from) {
+			bookings = append(bookings, b)
+		}
+	}
+	return bookings, nil
+}
+
+func (f testBookings) ListActiveHolds(ctx context.Context, resourceIDs []int, from, to, now time.Time) ([]booking.Hold, error) {
+	var holds []booking.Hold
+	for _, h := range f.holds {
+		if h.StartAt.Before(to) && h.EndAt.After(from) {
+			holds = append(holds, h)
+		}
+	}
+	return holds, nil
+}
+
+func (f testBookings) GetResourceVersions(ctx context.Context, resourceIDs []int) ([]int64, error) {
+	return make([]int64, len(resourceIDs)), nil
+}
+
+// testCache never hit so every search is computed
+type testCache struct{}
+
+func (testCache) GetCachedResult(ctx context.Context, key string) (Result, error) {
+	return Result{}, redis.ErrNil
+}
+
+func (testCache) CacheResult(ctx context.Context, key string, result Result, ttl time.Duration) error {
+	return nil
+}
+
+// memoryCache keep the cached searches and their ttl in memory
+type memoryCache struct {
+	results map[string]Result
+	ttls    map[string]time.Duration
+}
+
+func (c memoryCache) GetCachedResult(ctx context.Context, key string) (Result, error) {
+	result, ok := c.results[key]
+	if !ok {
+		return Result{}, redis.ErrNil
+	}
+	return result, nil
+}
+
+func (c memoryCache) CacheResult(ctx context.Context, key string, result Result, ttl time.Duration) error {
+	c.results[key] = result
+	c.ttls[key] = ttl
+	return nil
+}
+
+// newTestService wire the real schedule and booking services on in-memory repositories
+func newTestService(v venue.Venue, resources []resource.Resource, bookings testBookings) Service {
+	return newCachedTestService(testCache{}, testVenues{venue: v}, resources, bookings)
+}
+
+func newCachedTestService(cache Repository, venues testVenues, resources []resource.Resource, bookings testBookings) Service {
+	resourceService := testResources{resources: resources}
+	schedules := schedule.NewService(testExceptions{}, venues, resourceService)
+	bookingService := booking.NewService(bookings, venues, resourceService, schedules, nil, config.Config{})
+	return NewService(cache, venues, resourceService, schedules, bookingService)
+}
+
+// openEveryDay return opening hours from open to close minute on every weekday
+func openEveryDay(open, close int) []venue.OpeningHour {
+	hours := make([]venue.OpeningHour, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		hours = append(hours, venue.OpeningHour{WeeklyHours: venue.WeeklyHours{Weekday: d, OpenMinute: open, CloseMinute: close}})
+	}
+	return hours
+}
+
+func TestAlignToStep(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	kolkata, err := time.LoadLocation("Asia/Kolkata")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// 2026-03-08 skip from 02:00 EST to 03:00 EDT, 2026-11-01 repeat 01:00-02:00
+	at := func(loc *time.Location, month time.Month, day, hour, min int) time.Time {
+		return time.Date(2026, month, day, hour, min, 0, 0, loc)
+	}
+	edt := time.FixedZone("EDT", -4*60*60)
+	est := time.FixedZone("EST", -5*60*60)
+
+	tests := []struct {
+		name string
+		t    time.Time
+		loc  *time.Location
+		step time.Duration
+		want time.Time
+	}{
+		{"aligned", at(newYork, time.June, 1, 10, 15), newYork, 15 * time.Minute, at(newYork, time.June, 1, 10, 15)},
+		{"round up", at(newYork, time.June, 1, 10, 7), newYork, 15 * time.Minute, at(newYork, time.June, 1, 10, 15)},
+		{"round up to next day", at(newYork, time.June, 1, 23, 50), newYork, 30 * time.Minute, at(newYork, time.June, 2, 0, 0)},
+		{"into spring forward gap", at(est, time.March, 8, 1, 50), newYork, 15 * time.Minute, at(edt, time.March, 8, 3, 0)},
+		{"over spring forward gap", at(est, time.March, 8, 1, 30), newYork, 2 * time.Hour, at(edt, time.March, 8, 4, 0)},
+		{"after spring forward", at(edt, time.March, 8, 3, 10), newYork, 90 * time.Minute, at(edt, time.March, 8, 4, 30)},
+		{"before fall back", at(edt, time.November, 1, 0, 30), newYork, 2 * time.Hour, at(est, time.November, 1, 2, 0)},
+		{"into repeated hour", at(edt, time.November, 1, 1, 50), newYork, 15 * time.Minute, at(est, time.November, 1, 1, 0)},
+		{"half hour offset", at(time.UTC, time.June, 1, 10, 0), kolkata, time.Hour, at(time.UTC, time.June, 1, 10, 30)},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := alignToStep(tt.t, tt.loc, tt.step); !got.Equal(tt.want) {
+				t.Errorf("alignToStep(%v, %v) = %v, want %v", tt.t, tt.step, got.In(tt.loc), tt.want.In(tt.loc))
+			}
+		})
+	}
+}
+
+func TestSlotsAcrossDST(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	tests := []struct {
+		name string
+		free schedule.Interval
+		want []string
+	}{
+		{
+			name: "spring forward",
+			free: schedule.Interval{Start: time.Date(2026, 3, 8, 0, 0, 0, 0, newYork), End: time.Date(2026, 3, 8, 8, 0, 0, 0, newYork)},
+			want: []string{"00:00 EST", "04:00 EDT", "06:00 EDT"},
+		},
+		{
+			name: "fall back",
+			free: schedule.Interval{Start: time.Date(2026, 11, 1, 0, 0, 0, 0, newYork), End: time.Date(2026, 11, 1, 6, 0, 0, 0, newYork)},
+			want: []string{"00:00 EDT", "02:00 EST", "04:00 EST"},
+		},
+	}
+	search := Search{Duration: time.Hour, Step: 2 * time.Hour}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []string
+			for _, slot := range slots(1, []schedule.Interval{tt.free}, newYork, search, time.Time{}) {
+				got = append(got, slot.Start.In(newYork).Format("15:04 MST"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchAvailabilityBuffer(t *testing.T) {
+	ctx := context.Background()
+	v := venue.Venue{ID: 1, Timezone: "UTC", OpeningHours: openEveryDay(9*60, 12*60)}
+	day := time.Now().UTC().AddDate(0, 0, 7)
+	date := day.Format(schedule.DateLayout)
+	at := func(hour, min int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, min, 0, 0, time.UTC)
+	}
+	bookedAt := func(startHour, startMin, endHour, endMin int) []booking.Booking {
+		return []booking.Booking{{ResourceID: 1, StartAt: at(startHour, startMin), EndAt: at(endHour, endMin)}}
+	}
+
+	tests := []struct {
+		name         string
+		bufferBefore int
+		bufferAfter  int
+		bookings     []booking.Booking
+		holds        []booking.Hold
+		want         []string
+	}{
+		{
+			name:     "no buffer",
+			bookings: bookedAt(10, 0, 11, 0),
+			want:     []string{"09:00", "09:15", "09:30", "11:00", "11:15", "11:30"},
+		},
+		{
+			name:         "buffer on both side of booking",
+			bufferBefore: 10,
+			bufferAfter:  5,
+			bookings:     bookedAt(10, 0, 11, 0),
+			want:         []string{"09:00", "09:15", "11:15", "11:30"},
+		},
+		{
+			name:        "buffer around hold",
+			bufferAfter: 15,
+			holds:       []booking.Hold{{ResourceID: 1, StartAt: at(10, 0), EndAt: at(11, 0)}},
+			want:        []string{"09:00", "09:15", "11:15", "11:30"},
+		},
+		{
+			name:         "buffer of booking before opening",
+			bufferBefore: 15,
+			bookings:     bookedAt(8, 30, 9, 0),
+			want:         []string{"09:15", "09:30", "09:45", "10:00", "10:15", "10:30", "10:45", "11:00", "11:15", "11:30"},
+		},
+		{
+			name:         "buffer covering the opening hours",
+			bufferBefore: 30,
+			bufferAfter:  30,
+			bookings:     bookedAt(10, 0, 11, 0),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true, BufferBefore: tt.bufferBefore, BufferAfter: tt.bufferAfter}
+			s := newTestService(v, []resource.Resource{r}, testBookings{bookings: tt.bookings, holds: tt.holds})
+			result, err := s.SearchAvailability(ctx, Search{
+				VenueID:   v.ID,
+				StartDate: date,
+				EndDate:   date,
+				Duration:  30 * time.Minute,
+				PartySize: 2,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			var got []string
+			for _, slot := range result.Slots {
+				got = append(got, slot.Start.Format("15:04"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("slots = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestSearchAvailabilityCache(t *testing.T) {
+	ctx := context.Background()
+	v := venue.Venue{ID: 1, Timezone: "UTC", OpeningHours: openEveryDay(9*60, 12*60)}
+	r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true}
+	day := time.Now().UTC().AddDate(0, 0, 7)
+	search := Search{
+		VenueID:   v.ID,
+		StartDate: day.Format(schedule.DateLayout),
+		EndDate:   day.Format(schedule.DateLayout),
+		Duration:  time.Hour,
+		PartySize: 2,
+	}
+	holdStart := time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.UTC)
+	hold := booking.Hold{ResourceID: r.ID, StartAt: holdStart, EndAt: holdStart.Add(time.Hour), ExpiresAt: time.Now().Add(10 * time.Second)}
+
+	cache := memoryCache{results: map[string]Result{}, ttls: map[string]time.Duration{}}
+	venues := testVenues{venue: v}
+	if _, err := newCachedTestService(cache, venues, []resource.Resource{r}, testBookings{}).SearchAvailability(ctx, search); err != nil {
+		t.Fatal(err)
+	}
+	// the hold is not seen until the cached search expire or the schedule change
+	if _, err := newCachedTestService(cache, venues, []resource.Resource{r}, testBookings{holds: []booking.Hold{hold}}).SearchAvailability(ctx, search); err != nil {
+		t.Fatal(err)
+	}
+	if len(cache.results) != 1 {
+		t.Fatalf("cached %d searches, want the second search read from the cache", len(cache.results))
+	}
+	for key, ttl := range cache.ttls {
+		if ttl != cacheTTL {
+			t.Errorf("ttl = %v, want %v", ttl, cacheTTL)
+		}
+		delete(cache.ttls, key)
+	}
+
+	venues.scheduleVersion++
+	result, err := newCachedTestService(cache, venues, []resource.Resource{r}, testBookings{holds: []booking.Hold{hold}}).SearchAvailability(ctx, search)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// only 09:00 and 11:00 are left around the hold
+	if len(cache.results) != 2 || len(result.Slots) != 2 {
+		t.Fatalf("cached %d searches with %d slots, want the search computed again after the schedule change", len(cache.results), len(result.Slots))
+	}
+	for _, ttl := range cache.ttls {
+		if ttl <= 0 || ttl > 10*time.Second {
+			t.Errorf("ttl = %v, want at most until the hold expire", ttl)
+		}
+	}
+}
+
+// BenchmarkSearchAvailability search a week of one hour slots on 50 busy resources,
+// the search must stay well under 100ms without the cache
+func BenchmarkSearchAvailability(b *testing.B) {
+	ctx := context.Background()
+	const resourceCount = 50
+	loc, err := time.LoadLocation("Asia/Jakarta")
+	if err != nil {
+		b.Fatal(err)
+	}
+	v := venue.Venue{ID: 1, Timezone: loc.String(), OpeningHours: openEveryDay(8*60, 22*60)}
+	first := time.Now().In(loc).AddDate(0, 0, 1)
+	first = time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+
+	var resources []resource.Resource
+	var bookings testBookings
+	for i := 1; i <= resourceCount; i++ {
+		resources = append(resources, resource.Resource{ID: i, VenueID: v.ID, Capacity: 6, Active: true, BufferAfter: 10})
+		for d := 0; d < 7; d++ {
+			day := first.AddDate(0, 0, d)
+			// four bookings and one hold a day at times depending on the resource
+			for _, hour := range []int{9, 12, 15, 18} {
+				start := day.Add(time.Duration(hour)*time.Hour + time.Duration(i%4)*15*time.Minute)
+				bookings.bookings = append(bookings.bookings, booking.Booking{ResourceID: i, StartAt: start, EndAt: start.Add(90 * time.Minute)})
+			}
+			start := day.Add(20*time.Hour + time.Duration(i%3)*30*time.Minute)
+			bookings.holds = append(bookings.holds, booking.Hold{ResourceID: i, StartAt: start, EndAt: start.Add(time.Hour)})
+		}
+	}
+	s := newTestService(v, resources, bookings)
+	search := Search{
+		VenueID:   v.ID,
+		StartDate: first.Format(schedule.DateLayout),
+		EndDate:   first.AddDate(0, 0, 6).Format(schedule.DateLayout),
+		Duration:  time.Hour,
+		PartySize: 2,
+		Limit:     maxLimit,
+	}
+
+	b.ReportAllocs()
+	b.ResetTimer()
+	for i := 0; i < b.N; i++ {
+		result, err := s.SearchAvailability(ctx, search)
+		if err != nil {
+			b.Fatal(err)
+		}
+		if len(result.Slots) == 0 {
+			b.Fatalf("no slot found for %d resources", resourceCount)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+)
+
+// CancelBooking give the slot back, the customer can only cancel before the booking
+// start and is refunded according to the cancellation policy of the resource at this
+// moment, the venue can cancel later and the customer is refunded entirely
+func (s *service) CancelBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, policy.Outcome, error) {
+	booking, byCustomer, err := s.checkTransition(ctx, actor, id, StatusCancelled)
+	if err != nil {
+		return Booking{}, policy.Outcome{}, err
+	}
+	outcome, err := s.cancellationOutcome(ctx, booking, byCustomer, time.Now())
+	if err != nil {
+		return Booking{}, policy.Outcome{}, err
+	}
+
+	booking, err = s.applyTransition(ctx, actor, booking, StatusCancelled, reason, map[string]interface{}{
+		"cancellation_fee": outcome.Fee,
+		"refund_amount":    outcome.Refund,
+	})
+	if err != nil {
+		return Booking{}, policy.Outcome{}, err
+	}
+	booking.CancellationFee = outcome.Fee
+	booking.RefundAmount = outcome.Refund
+	return booking, outcome, nil
+}
+
+// PreviewCancellation return the outcome CancelBooking would have now without cancelling
+func (s *service) PreviewCancellation(ctx context.Context, actor server.AuthInfo, id int) (Booking, policy.Outcome, error) {
+	booking, byCustomer, err := s.checkTransition(ctx, actor, id, StatusCancelled)
+	if err != nil {
+		return Booking{}, policy.Outcome{}, err
+	}
+	outcome, err := s.cancellationOutcome(ctx, booking, byCustomer, time.Now())
+	if err != nil {
+		return Booking{}, policy.Outcome{}, err
+	}
+	return booking, outcome, nil
+}
+
+func (s *service) cancellationOutcome(ctx context.Context, booking Booking, byCustomer bool, now time.Time) (policy.Outcome, error) {
+	if !byCustomer {
+		return policy.FullRefund(booking.Price), nil
+	}
+	p, err := s.resourcePolicy(ctx, booking.ResourceID)
+	if err != nil {
+		return policy.Outcome{}, err
+	}
+	return policy.Evaluate(p, booking.Price, booking.StartAt, now), nil
+}
+
+// resourcePolicy return the current cancellation policy of the resource, nil when
+// there is none or the resource was deleted
+func (s *service) resourcePolicy(ctx context.Context, resourceID int) (*policy.Policy, error) {
+	r, err := s.resources.GetResource(ctx, resourceID)
+	if errors.Is(err, resource.ErrResourceNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if r.CancellationPolicyID == nil {
+		return nil, nil
+	}
+	p, err := s.policies.GetPolicy(ctx, *r.CancellationPolicyID)
+	if errors.Is(err, policy.ErrPolicyNotFound) {
+		return nil, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return &p, nil
+}
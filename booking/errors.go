@@ -0,0 +1,59 @@
+package booking
+
+import (
+	"strings"
+
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidInterval     = status.Error(codes.InvalidArgument, "booking must end after it starts and last at most 24 hours")
+	ErrBookingInPast       = status.Error(codes.InvalidArgument, "booking cannot start in the past")
+	ErrInvalidPartySize    = status.Error(codes.InvalidArgument, "party size must be between 1 and the resource capacity")
+	ErrResourceNotBookable = status.Error(codes.FailedPrecondition, "resource is not bookable")
+	ErrOutsideOpeningHours = status.Error(codes.FailedPrecondition, "resource is closed during the requested time")
+	ErrSlotUnavailable     = status.Error(codes.AlreadyExists, "the requested time is no longer available")
+	ErrBookingNotFound     = status.Error(codes.NotFound, "booking not found")
+	ErrHoldNotFound        = status.Error(codes.NotFound, "hold not found")
+	ErrHoldExpired         = status.Error(codes.FailedPrecondition, "hold has expired or was already used")
+	ErrTooManyHolds        = status.Error(codes.ResourceExhausted, "too many slots held at this venue, book or release one first")
+	ErrInvalidReason       = status.Error(codes.InvalidArgument, "reason must be at most 500 characters")
+	ErrNotStarted          = status.Error(codes.FailedPrecondition, "booking has not started yet")
+	ErrAlreadyStarted      = status.Error(codes.FailedPrecondition, "booking has already started, please contact the venue")
+	ErrAlreadyEnded        = status.Error(codes.FailedPrecondition, "booking has already ended")
+	ErrOtherVenue          = status.Error(codes.InvalidArgument, "booking can only be moved to a resource of the same venue")
+	ErrSameSlot            = status.Error(codes.InvalidArgument, "new slot is the same as the current one")
+	ErrCurrencyMismatch    = status.Error(codes.FailedPrecondition, "new resource is priced in another currency")
+	ErrInvalidRRule        = status.Error(codes.InvalidArgument, "rrule must be a daily, weekly or monthly RFC 5545 rule with COUNT or UNTIL, e.g. FREQ=WEEKLY;BYDAY=TU;COUNT=10")
+	ErrTooManyOccurrences  = status.Error(codes.InvalidArgument, "a series can have at most 52 occurrences")
+	ErrInvalidCancelScope  = status.Error(codes.InvalidArgument, "scope must be one, following or all")
+	ErrNotInSeries         = status.Error(codes.FailedPrecondition, "booking is not part of a series")
+	ErrSeriesNotFound      = status.Error(codes.NotFound, "booking series not found")
+	ErrStatusChanged       = status.Error(codes.Aborted, "booking was changed at the same time, please try again")
+	ErrInternal            = status.Error(codes.Internal, "internal server error")
+)
+
+// transitionVerbs describe the move to the status in error messages
+var transitionVerbs = map[Status]string{
+	StatusConfirmed: "confirmed",
+	StatusCheckedIn: "checked in",
+	StatusCompleted: "completed",
+	StatusCancelled: "cancelled",
+	StatusNoShow:    "marked as no-show",
+	StatusExpired:   "expired",
+}
+
+// invalidTransition is the error returned when the booking cannot be changed in its status,
+// e.g. booking is cancelled and cannot be checked in
+func invalidTransition(from Status, verb string) error {
+	return status.Errorf(codes.FailedPrecondition, "booking is %s and cannot be %s",
+		strings.Replace(string(from), "_", "-", 1), verb)
+}
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[booking] %v", err)
+	return ErrInternal
+}
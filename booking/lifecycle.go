@@ -0,0 +1,216 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/venue"
+	"gorm.io/gorm"
+)
+
+const (
+	maxReasonLength = 500
+	// expireBatchSize is number of pending bookings expired per query
+	expireBatchSize = 100
+)
+
+// transitionRule tell from which statuses and by whom a booking can move to a status
+type transitionRule struct {
+	from []Status
+	// customer allow the customer of the booking to make the transition before the booking start
+	customer bool
+}
+
+var transitionRules = map[Status]transitionRule{
+	StatusConfirmed: {from: []Status{StatusPending}},
+	StatusCheckedIn: {from: []Status{StatusConfirmed}},
+	StatusCompleted: {from: []Status{StatusCheckedIn}},
+	StatusCancelled: {from: []Status{StatusPending, StatusConfirmed}, customer: true},
+	StatusNoShow:    {from: []Status{StatusConfirmed}},
+	StatusExpired:   {from: []Status{StatusPending}},
+}
+
+// ConfirmBooking accept a pending booking
+func (s *service) ConfirmBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error) {
+	return s.transition(ctx, actor, id, StatusConfirmed, reason)
+}
+
+// CheckInBooking record the arrival of the customer, it is not allowed after the booking ended
+func (s *service) CheckInBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error) {
+	return s.transition(ctx, actor, id, StatusCheckedIn, reason)
+}
+
+func (s *service) CompleteBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error) {
+	return s.transition(ctx, actor, id, StatusCompleted, reason)
+}
+
+// MarkNoShow record that the customer did not come, it is allowed once the booking started
+func (s *service) MarkNoShow(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error) {
+	return s.transition(ctx, actor, id, StatusNoShow, reason)
+}
+
+// ExpireBooking expire a pending booking which started without being confirmed
+func (s *service) ExpireBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error) {
+	return s.transition(ctx, actor, id, StatusExpired, reason)
+}
+
+func (s *service) ListTransitions(ctx context.Context, actor server.AuthInfo, id int) ([]Transition, error) {
+	if _, err := s.GetBooking(ctx, actor, id); err != nil {
+		return nil, err
+	}
+	transitions, err := s.repo.ListTransitions(ctx, id)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return transitions, nil
+}
+
+// ExpirePendingBookings expire pending bookings which were not confirmed
+// before they started, it return number of expired bookings
+func (s *service) ExpirePendingBookings(ctx context.Context) (int, error) {
+	count := 0
+	for {
+		bookings, err := s.repo.ListPendingBookingsStartedBefore(ctx, time.Now(), expireBatchSize)
+		if err != nil {
+			return count, err
+		}
+		for _, booking := range bookings {
+			err := s.repo.TransitionBooking(ctx, &Transition{
+				BookingID:  booking.ID,
+				FromStatus: StatusPending,
+				ToStatus:   StatusExpired,
+				Reason:     "not confirmed before the start",
+			}, nil)
+			// confirmed or cancelled in the meantime
+			if errors.Is(err, errStatusChanged) {
+				continue
+			}
+			if err != nil {
+				return count, err
+			}
+			s.resourceChanged(ctx, booking.ResourceID)
+			count++
+		}
+		if len(bookings) < expireBatchSize {
+			break
+		}
+	}
+	if count > 0 {
+		logger.Infof("[booking] expired %d pending bookings", count)
+	}
+	return count, nil
+}
+
+// transition move the booking to the status if the rule of the status allow it
+func (s *service) transition(ctx context.Context, actor server.AuthInfo, id int, to Status, reason string) (Booking, error) {
+	booking, _, err := s.checkTransition(ctx, actor, id, to)
+	if err != nil {
+		return Booking{}, err
+	}
+	return s.applyTransition(ctx, actor, booking, to, reason, nil)
+}
+
+// checkTransition return the booking if the actor can move it to the status now,
+// byCustomer tell whether the actor do it as the customer of the booking
+func (s *service) checkTransition(ctx context.Context, actor server.AuthInfo, id int, to Status) (booking Booking, byCustomer bool, err error) {
+	booking, err = s.repo.GetBooking(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Booking{}, false, ErrBookingNotFound
+	}
+	if err != nil {
+		return Booking{}, false, internalError(err)
+	}
+
+	rule := transitionRules[to]
+	byCustomer, err = s.authorizeTransition(ctx, actor, booking, rule)
+	if err != nil {
+		return Booking{}, false, err
+	}
+	if !statusIn(booking.Status, rule.from) {
+		return Booking{}, false, invalidTransition(booking.Status, transitionVerbs[to])
+	}
+	if err := checkTransitionTime(booking, to, time.Now()); err != nil {
+		return Booking{}, false, err
+	}
+	return booking, byCustomer, nil
+}
+
+// applyTransition save the status and the other fields together with the transition record
+func (s *service) applyTransition(ctx context.Context, actor server.AuthInfo, booking Booking, to Status, reason string, fields map[string]interface{}) (Booking, error) {
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxReasonLength {
+		return Booking{}, ErrInvalidReason
+	}
+
+	err := s.repo.TransitionBooking(ctx, &Transition{
+		BookingID:  booking.ID,
+		FromStatus: booking.Status,
+		ToStatus:   to,
+		ActorID:    actor.UserID,
+		Reason:     reason,
+	}, fields)
+	if errors.Is(err, errStatusChanged) {
+		return Booking{}, ErrStatusChanged
+	}
+	if err != nil {
+		return Booking{}, internalError(err)
+	}
+	if !to.IsOccupying() {
+		s.resourceChanged(ctx, booking.ResourceID)
+	}
+	booking.Status = to
+	return booking, nil
+}
+
+// authorizeTransition allow the venue manager and staff, and the customer granted booking:create
+// when the rule allow it before the booking start
+func (s *service) authorizeTransition(ctx context.Context, actor server.AuthInfo, booking Booking, rule transitionRule) (bool, error) {
+	customer := booking.UserID == actor.UserID && rule.customer
+	if customer && actor.HasPermission(server.PermissionBookingCreate) {
+		if !booking.StartAt.After(time.Now()) {
+			return false, ErrAlreadyStarted
+		}
+		return true, nil
+	}
+
+	_, err := s.venues.GetStaffedVenue(ctx, actor, booking.VenueID)
+	if errors.Is(err, venue.ErrNotVenueManager) {
+		if booking.UserID != actor.UserID {
+			// do not reveal bookings of other customers
+			return false, ErrBookingNotFound
+		}
+		if customer {
+			return false, server.PermissionDenied(server.PermissionBookingCreate)
+		}
+	}
+	return false, err
+}
+
+// checkTransitionTime make sure the move fit the time of the booking
+func checkTransitionTime(booking Booking, to Status, now time.Time) error {
+	switch to {
+	case StatusCheckedIn:
+		if !booking.EndAt.After(now) {
+			return ErrAlreadyEnded
+		}
+	case StatusNoShow, StatusExpired:
+		if booking.StartAt.After(now) {
+			return ErrNotStarted
+		}
+	}
+	return nil
+}
+
+func statusIn(status Status, statuses []Status) bool {
+	for _, s := range statuses {
+		if status == s {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,176 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+)
+
+func TestTransitionRoles(t *testing.T) {
+	ctx := context.Background()
+	const (
+		customerID   = 10
+		ownerID      = 20
+		staffID      = 30
+		otherStaffID = 31
+		// limitedStaffID work at the venue with an api key restricted to booking:create
+		limitedStaffID = 32
+	)
+	v := venue.Venue{ID: 1, OwnerID: ownerID, Timezone: "UTC", OpeningHours: openEveryDay(0, 24*60)}
+	venues := testVenueRepository{venue: v, staff: []int{staffID, limitedStaffID}}
+	r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true}
+
+	create := "booking:create"
+	manage := server.PermissionBookingManage
+	actors := []struct {
+		name  string
+		actor server.AuthInfo
+		// allowed is the transition the actor can make, nil allow every transition
+		allowed []Status
+		// err is returned for the other transitions
+		err error
+	}{
+		{"customer", server.AuthInfo{UserID: customerID, Role: "customer", Permissions: []string{create}}, []Status{StatusCancelled}, venue.ErrNotVenueManager},
+		{"other customer", server.AuthInfo{UserID: 11, Role: "customer", Permissions: []string{create}}, []Status{}, ErrBookingNotFound},
+		{"venue staff", server.AuthInfo{UserID: staffID, Role: "venue_staff", Permissions: []string{create, manage}}, nil, nil},
+		{"staff of other venue", server.AuthInfo{UserID: otherStaffID, Role: "venue_staff", Permissions: []string{create, manage}}, []Status{}, ErrBookingNotFound},
+		{"staff without booking:manage", server.AuthInfo{UserID: limitedStaffID, Role: "venue_staff", Permissions: []string{create}}, []Status{}, ErrBookingNotFound},
+		{"venue owner", server.AuthInfo{UserID: ownerID, Role: "venue_admin", Permissions: []string{create, manage, "venue:manage"}}, nil, nil},
+		{"other venue admin", server.AuthInfo{UserID: 21, Role: "venue_admin", Permissions: []string{create, manage, "venue:manage"}}, []Status{}, ErrBookingNotFound},
+		{"platform admin", server.AuthInfo{UserID: 40, Role: server.RolePlatformAdmin, Permissions: []string{create, manage, "venue:manage", "user:manage"}}, nil, nil},
+	}
+
+	type action func(s *service, actor server.AuthInfo, id int) error
+	actions := map[Status]action{
+		StatusConfirmed: func(s *service, actor server.AuthInfo, id int) error {
+			_, err := s.ConfirmBooking(ctx, actor, id, "")
+			return err
+		},
+		StatusCheckedIn: func(s *service, actor server.AuthInfo, id int) error {
+			_, err := s.CheckInBooking(ctx, actor, id, "")
+			return err
+		},
+		StatusCompleted: func(s *service, actor server.AuthInfo, id int) error {
+			_, err := s.CompleteBooking(ctx, actor, id, "")
+			return err
+		},
+		StatusCancelled: func(s *service, actor server.AuthInfo, id int) error {
+			_, _, err := s.CancelBooking(ctx, actor, id, "")
+			return err
+		},
+		StatusNoShow: func(s *service, actor server.AuthInfo, id int) error {
+			_, err := s.MarkNoShow(ctx, actor, id, "")
+			return err
+		},
+		StatusExpired: func(s *service, actor server.AuthInfo, id int) error {
+			_, err := s.ExpireBooking(ctx, actor, id, "")
+			return err
+		},
+	}
+
+	now := time.Now().UTC().Truncate(time.Minute)
+	for to, rule := range transitionRules {
+		act, ok := actions[to]
+		if !ok {
+			t.Fatalf("no action for the transition to %s", to)
+		}
+		// bookings which can be marked no-show or expired have started,
+		// the others start later so the customer can still cancel
+		startAt := now.Add(time.Hour)
+		if to == StatusNoShow || to == StatusExpired {
+			startAt = now.Add(-10 * time.Minute)
+		}
+		booking := Booking{
+			ID:         1,
+			VenueID:    v.ID,
+			ResourceID: r.ID,
+			UserID:     customerID,
+			StartAt:    startAt,
+			EndAt:      startAt.Add(time.Hour),
+			PartySize:  2,
+			Status:     rule.from[0],
+		}
+
+		for _, a := range actors {
+			t.Run(string(to)+"/"+a.name, func(t *testing.T) {
+				repo := newTestRepository(booking)
+				s := newTestService(repo, venues, r)
+
+				var want error
+				if a.allowed != nil && !statusIn(to, a.allowed) {
+					want = a.err
+				}
+				err := act(s, a.actor, booking.ID)
+				if !errors.Is(err, want) {
+					t.Fatalf("got error %v, want %v", err, want)
+				}
+
+				stored, _ := repo.GetBooking(ctx, booking.ID)
+				if want == nil && stored.Status != to {
+					t.Errorf("status = %s, want %s", stored.Status, to)
+				}
+				if want != nil && (stored.Status != booking.Status || len(repo.transitions) != 0) {
+					t.Errorf("booking changed to %s by a rejected transition", stored.Status)
+				}
+			})
+		}
+	}
+}
+
+// TestCustomerWithoutBookingCreate make sure the customer need booking:create to
+// cancel or move the own booking, e.g. with an api key scoped to other permissions
+func TestCustomerWithoutBookingCreate(t *testing.T) {
+	ctx := context.Background()
+	const customerID = 10
+	v := venue.Venue{ID: 1, OwnerID: 20, Timezone: "UTC", OpeningHours: openEveryDay(0, 24*60)}
+	r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true}
+	seriesID := 3
+	start := time.Now().UTC().Truncate(time.Hour).Add(48 * time.Hour)
+	booking := Booking{
+		ID:         1,
+		VenueID:    v.ID,
+		ResourceID: r.ID,
+		UserID:     customerID,
+		StartAt:    start,
+		EndAt:      start.Add(time.Hour),
+		PartySize:  2,
+		Status:     StatusConfirmed,
+		SeriesID:   &seriesID,
+	}
+	actor := server.AuthInfo{UserID: customerID, Role: "customer", Permissions: []string{"venue:read"}}
+	want := server.PermissionDenied(server.PermissionBookingCreate)
+
+	actions := map[string]func(s *service) error{
+		"cancel": func(s *service) error {
+			_, _, err := s.CancelBooking(ctx, actor, booking.ID, "")
+			return err
+		},
+		"reschedule": func(s *service) error {
+			_, _, err := s.RescheduleBooking(ctx, actor, booking.ID, RescheduleBooking{StartAt: start.Add(time.Hour), EndAt: start.Add(2 * time.Hour)})
+			return err
+		},
+		"cancel series": func(s *service) error {
+			_, err := s.CancelSeriesBooking(ctx, actor, booking.ID, CancelScopeAll, "")
+			return err
+		},
+	}
+	for name, act := range actions {
+		t.Run(name, func(t *testing.T) {
+			repo := newTestRepository(booking)
+			s := newTestService(repo, testVenueRepository{venue: v}, r)
+
+			if err := act(s); !errors.Is(err, want) {
+				t.Fatalf("got error %v, want %v", err, want)
+			}
+			stored, _ := repo.GetBooking(ctx, booking.ID)
+			if stored != booking || len(repo.transitions) != 0 {
+				t.Errorf("booking changed to %+v", stored)
+			}
+		})
+	}
+}
@@ -0,0 +1,199 @@
+package booking
+
+import (
+	"time"
+
+	"github.com/booking-man-be/policy"
+)
+
+// Status of the booking
+type Status string
+
+const (
+	// StatusPending booking wait for the venue to confirm it
+	StatusPending   Status = "pending"
+	StatusConfirmed Status = "confirmed"
+	StatusCheckedIn Status = "checked_in"
+	StatusCompleted Status = "completed"
+	StatusCancelled Status = "cancelled"
+	StatusNoShow    Status = "no_show"
+	// StatusExpired pending booking was not confirmed before it started
+	StatusExpired Status = "expired"
+)
+
+// occupyingStatuses is the statuses which keep the resource busy
+var occupyingStatuses = []Status{StatusPending, StatusConfirmed, StatusCheckedIn, StatusCompleted}
+
+// IsOccupying check whether the booking in this status keep the resource busy
+func (s Status) IsOccupying() bool {
+	return statusIn(s, occupyingStatuses)
+}
+
+// Booking reserve a resource for the interval [StartAt, EndAt)
+type Booking struct {
+	ID         int       `gorm:"primary_key"`
+	VenueID    int       `gorm:"not null;index"`
+	ResourceID int       `gorm:"not null;index:idx_booking_resource_time"`
+	UserID     int       `gorm:"not null;index"`
+	StartAt    time.Time `gorm:"not null;index:idx_booking_resource_time"`
+	EndAt      time.Time `gorm:"not null"`
+	PartySize  int       `gorm:"not null"`
+	Status     Status    `gorm:"type:varchar(20);not null"`
+	// SeriesID is set for an occurrence of a recurring booking
+	SeriesID *int `gorm:"index"`
+	// Price is charged for the booking in minor unit of the currency
+	Price    int64  `gorm:"not null;default:0"`
+	Currency string `gorm:"type:char(3);not null;default:''"`
+	// CancellationFee and RefundAmount are set when the booking is cancelled
+	CancellationFee int64 `gorm:"not null;default:0"`
+	RefundAmount    int64 `gorm:"not null;default:0"`
+	// RescheduleFees is the sum of the fees charged for moving the booking
+	RescheduleFees int64     `gorm:"not null;default:0"`
+	CreatedAt      time.Time `gorm:"not null"`
+	UpdatedAt      time.Time `gorm:"not null"`
+}
+
+// Series is a recurring booking, every occurrence is materialised as a booking
+type Series struct {
+	ID         int `gorm:"primary_key"`
+	VenueID    int `gorm:"not null;index"`
+	ResourceID int `gorm:"not null"`
+	UserID     int `gorm:"not null;index"`
+	// RRule is RFC 5545 recurrence rule without DTSTART, e.g. FREQ=WEEKLY;BYDAY=TU;COUNT=10
+	RRule string `gorm:"type:varchar(500);not null"`
+	// StartAt and EndAt are the first occurrence, it is repeated on the venue clock
+	StartAt   time.Time `gorm:"not null"`
+	EndAt     time.Time `gorm:"not null"`
+	PartySize int       `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (Series) TableName() string {
+	return "booking_series"
+}
+
+// Transition record a status change of the booking
+type Transition struct {
+	ID        int `gorm:"primary_key"`
+	BookingID int `gorm:"not null;index"`
+	// FromStatus is empty for the creation of the booking
+	FromStatus Status `gorm:"type:varchar(20);not null"`
+	ToStatus   Status `gorm:"type:varchar(20);not null"`
+	// ActorID is the user who made the change, zero when it was done by the system
+	ActorID   int       `gorm:"not null"`
+	Reason    string    `gorm:"type:varchar(500);not null"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (Transition) TableName() string {
+	return "booking_transition"
+}
+
+// Hold keep a slot for the customer during checkout until it expire,
+// it is released or converted into a booking
+type Hold struct {
+	ID         int       `gorm:"primary_key"`
+	VenueID    int       `gorm:"not null;index"`
+	ResourceID int       `gorm:"not null;index:idx_booking_hold_resource_time"`
+	UserID     int       `gorm:"not null;index"`
+	StartAt    time.Time `gorm:"not null;index:idx_booking_hold_resource_time"`
+	EndAt      time.Time `gorm:"not null"`
+	PartySize  int       `gorm:"not null"`
+	ExpiresAt  time.Time `gorm:"not null"`
+	// BookingID is set once the hold is converted into a booking
+	BookingID  *int
+	ReleasedAt *time.Time
+	CreatedAt  time.Time `gorm:"not null"`
+}
+
+func (Hold) TableName() string {
+	return "booking_hold"
+}
+
+// IsActive check whether the hold still keep the slot
+func (h Hold) IsActive(now time.Time) bool {
+	return h.BookingID == nil && h.ReleasedAt == nil && h.ExpiresAt.After(now)
+}
+
+type CreateBooking struct {
+	// HoldID convert the hold into the booking, the other fields are taken from the hold
+	HoldID     int
+	ResourceID int
+	StartAt    time.Time
+	EndAt      time.Time
+	PartySize  int
+}
+
+type HoldSlot struct {
+	ResourceID int
+	StartAt    time.Time
+	EndAt      time.Time
+	PartySize  int
+}
+
+// RescheduleBooking is the new slot of a booking, zero ResourceID and PartySize keep the current ones
+type RescheduleBooking struct {
+	ResourceID int
+	StartAt    time.Time
+	EndAt      time.Time
+	PartySize  int
+	Reason     string
+}
+
+// Reschedule is the price change of a moved booking, amounts are in minor currency unit
+type Reschedule struct {
+	OldPrice int64
+	NewPrice int64
+	// Fee is charged on top of the price difference
+	Fee policy.Outcome
+}
+
+type CreateSeries struct {
+	ResourceID int
+	// StartAt and EndAt are the first occurrence
+	StartAt   time.Time
+	EndAt     time.Time
+	RRule     string
+	PartySize int
+	// AcceptPartial book the free occurrences when some conflict,
+	// otherwise nothing is booked unless every occurrence is free
+	AcceptPartial bool
+}
+
+// Conflict tell why an occurrence of a series cannot be booked
+type Conflict string
+
+const (
+	// ConflictUnavailable occurrence overlap another booking or hold
+	ConflictUnavailable Conflict = "unavailable"
+	// ConflictClosed occurrence is outside the opening hours of the resource
+	ConflictClosed Conflict = "closed"
+	// ConflictPast occurrence start in the past
+	ConflictPast Conflict = "past"
+)
+
+// Occurrence is one repetition of a series
+type Occurrence struct {
+	StartAt time.Time
+	EndAt   time.Time
+	// BookingID is set when the occurrence was booked
+	BookingID int
+	// Conflict is empty when the occurrence is free
+	Conflict Conflict
+}
+
+// CancelScope is which occurrences of a series are cancelled together with the booking
+type CancelScope string
+
+const (
+	CancelScopeOne CancelScope = "one"
+	// CancelScopeFollowing cancel the booking and every later occurrence
+	CancelScopeFollowing CancelScope = "following"
+	CancelScopeAll       CancelScope = "all"
+)
+
+// Cancellation is a cancelled booking and its refund
+type Cancellation struct {
+	Booking Booking
+	Outcome policy.Outcome
+}
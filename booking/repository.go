@@ -0,0 +1,520 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// redis keys
+const (
+	// keyResourceVersion is incremented on every booking change of the resource,
+	// it is part of availability cache key so stale entries are never read
+	keyResourceVersion = "booking:resource_version:%d"
+	// keyHold exist until the hold expire, it is mirrored by booking_hold table
+	keyHold = "booking:hold:%d"
+)
+
+var (
+	// errOverlap is returned when the interval is already taken
+	errOverlap = errors.New("booking overlap")
+	// errHoldInactive is returned when the converted hold expired, was released or already converted
+	errHoldInactive = errors.New("hold is not active")
+	// errTooManyHolds is returned when the user already hold the most slots allowed at the venue
+	errTooManyHolds = errors.New("too many active holds")
+	// errStatusChanged is returned when the booking status is not the expected one anymore
+	errStatusChanged = errors.New("booking status changed")
+)
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	CreateBooking(ctx context.Context, booking *Booking, buffer time.Duration, holdID int) error
+	GetBooking(ctx context.Context, id int) (Booking, error)
+	ListOccupyingBookings(ctx context.Context, resourceIDs []int, from, to time.Time) ([]Booking, error)
+	ListPendingBookingsStartedBefore(ctx context.Context, before time.Time, limit int) ([]Booking, error)
+
+	CreateSeries(ctx context.Context, series *Series, bookings []*Booking, buffer time.Duration, acceptPartial bool) ([]int, error)
+	GetSeries(ctx context.Context, id int) (Series, error)
+	ListSeriesBookings(ctx context.Context, seriesID int, from time.Time) ([]Booking, error)
+	CancelSeriesBookings(ctx context.Context, seriesID int, cancellations []Cancellation, actorID int, reason string) error
+
+	RescheduleBooking(ctx context.Context, current Booking, moved Booking, buffer time.Duration, transition *Transition) error
+	TransitionBooking(ctx context.Context, transition *Transition, fields map[string]interface{}) error
+	ListTransitions(ctx context.Context, bookingID int) ([]Transition, error)
+
+	CreateHold(ctx context.Context, hold *Hold, buffer time.Duration, maxActive int) error
+	GetHold(ctx context.Context, id int) (Hold, error)
+	ReleaseHold(ctx context.Context, id int, now time.Time) error
+	ListActiveHolds(ctx context.Context, resourceIDs []int, from, to, now time.Time) ([]Hold, error)
+	SetHoldKey(ctx context.Context, id int, ttl time.Duration) error
+	HoldKeyExists(ctx context.Context, id int) (bool, error)
+	DeleteHoldKey(ctx context.Context, id int) error
+
+	GetResourceVersions(ctx context.Context, resourceIDs []int) ([]int64, error)
+	IncrResourceVersion(ctx context.Context, resourceID int) error
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+// CreateBooking insert the booking unless an occupying booking or active hold of
+// the resource is closer than buffer, the resource row is locked for the duration
+// of the transaction so concurrent bookings of the same resource are serialized.
+// When holdID is not zero the hold is converted and does not conflict with the booking
+func (r *repository) CreateBooking(ctx context.Context, booking *Booking, buffer time.Duration, holdID int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockResource(tx, booking.ResourceID); err != nil {
+			return err
+		}
+		now := time.Now()
+		if holdID != 0 {
+			var count int64
+			err := tx.Model(&Hold{}).
+				Where("id = ? AND booking_id IS NULL AND released_at IS NULL AND expires_at > ?", holdID, now).
+				Count(&count).Error
+			if err != nil {
+				return err
+			}
+			if count == 0 {
+				return errHoldInactive
+			}
+		}
+
+		if err := checkOverlap(tx, booking.ResourceID, booking.StartAt.Add(-buffer), booking.EndAt.Add(buffer), now, 0, holdID); err != nil {
+			return err
+		}
+		if err := tx.Create(booking).Error; err != nil {
+			return err
+		}
+		err := tx.Create(&Transition{
+			BookingID: booking.ID,
+			ToStatus:  booking.Status,
+			ActorID:   booking.UserID,
+		}).Error
+		if err != nil {
+			return err
+		}
+		if holdID != 0 {
+			return tx.Model(&Hold{}).Where("id = ?", holdID).Update("booking_id", booking.ID).Error
+		}
+		return nil
+	})
+}
+
+func (r *repository) GetBooking(ctx context.Context, id int) (Booking, error) {
+	var booking Booking
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&booking).Error
+	return booking, err
+}
+
+// ListOccupyingBookings return bookings keeping the resources busy which overlap [from, to)
+func (r *repository) ListOccupyingBookings(ctx context.Context, resourceIDs []int, from, to time.Time) ([]Booking, error) {
+	var bookings []Booking
+	err := r.db.WithContext(ctx).
+		Select("id, resource_id, start_at, end_at").
+		Where("resource_id IN ? AND start_at < ? AND end_at > ? AND status IN ?", resourceIDs, to, from, occupyingStatuses).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// ListPendingBookingsStartedBefore return the oldest pending bookings which started before the time
+func (r *repository) ListPendingBookingsStartedBefore(ctx context.Context, before time.Time, limit int) ([]Booking, error) {
+	var bookings []Booking
+	err := r.db.WithContext(ctx).
+		Where("status = ? AND start_at <= ?", StatusPending, before).
+		Order("start_at").
+		Limit(limit).
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// CreateSeries insert the series and its bookings with the same guarantee as CreateBooking,
+// it return the index of the bookings which overlap an existing booking, hold or an
+// earlier booking of the series. When acceptPartial is false nothing is inserted unless
+// every booking is free, otherwise only the free ones are inserted. Nothing is inserted
+// when no booking is free
+func (r *repository) CreateSeries(ctx context.Context, series *Series, bookings []*Booking, buffer time.Duration, acceptPartial bool) ([]int, error) {
+	var conflicts []int
+	err := r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockResource(tx, series.ResourceID); err != nil {
+			return err
+		}
+
+		now := time.Now()
+		var free []*Booking
+		for i, b := range bookings {
+			err := checkOverlap(tx, b.ResourceID, b.StartAt.Add(-buffer), b.EndAt.Add(buffer), now, 0, 0)
+			if err == nil && overlapAny(free, b, buffer) {
+				err = errOverlap
+			}
+			if errors.Is(err, errOverlap) {
+				conflicts = append(conflicts, i)
+				continue
+			}
+			if err != nil {
+				return err
+			}
+			free = append(free, b)
+		}
+		if len(free) == 0 || (len(conflicts) > 0 && !acceptPartial) {
+			return nil
+		}
+
+		if err := tx.Create(series).Error; err != nil {
+			return err
+		}
+		for _, b := range free {
+			b.SeriesID = &series.ID
+			if err := tx.Create(b).Error; err != nil {
+				return err
+			}
+			err := tx.Create(&Transition{
+				BookingID: b.ID,
+				ToStatus:  b.Status,
+				ActorID:   b.UserID,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	return conflicts, err
+}
+
+// GetSeries return gorm.ErrRecordNotFound if the series does not exist
+func (r *repository) GetSeries(ctx context.Context, id int) (Series, error) {
+	var series Series
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&series).Error
+	return series, err
+}
+
+// ListSeriesBookings return the bookings of the series starting from the time
+func (r *repository) ListSeriesBookings(ctx context.Context, seriesID int, from time.Time) ([]Booking, error) {
+	var bookings []Booking
+	err := r.db.WithContext(ctx).
+		Where("series_id = ? AND start_at >= ?", seriesID, from).
+		Order("start_at").
+		Find(&bookings).Error
+	return bookings, err
+}
+
+// CancelSeriesBookings cancel the bookings of the series with their fee and refund and
+// record the transitions in a single transaction, the bookings of the series are locked
+// first. Nothing is cancelled and errStatusChanged is returned when one of the bookings
+// is not in the status it was read with anymore
+func (r *repository) CancelSeriesBookings(ctx context.Context, seriesID int, cancellations []Cancellation, actorID int, reason string) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var locked []Booking
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Where("series_id = ?", seriesID).
+			Order("id").
+			Find(&locked).Error
+		if err != nil {
+			return err
+		}
+
+		for _, c := range cancellations {
+			result := tx.Model(&Booking{}).
+				Where("id = ? AND series_id = ? AND status = ?", c.Booking.ID, seriesID, c.Booking.Status).
+				Updates(map[string]interface{}{
+					"status":           StatusCancelled,
+					"cancellation_fee": c.Outcome.Fee,
+					"refund_amount":    c.Outcome.Refund,
+				})
+			if result.Error != nil {
+				return result.Error
+			}
+			if result.RowsAffected == 0 {
+				return errStatusChanged
+			}
+			err := tx.Create(&Transition{
+				BookingID:  c.Booking.ID,
+				FromStatus: c.Booking.Status,
+				ToStatus:   StatusCancelled,
+				ActorID:    actorID,
+				Reason:     reason,
+			}).Error
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// RescheduleBooking replace the current slot of the booking by the moved one in a
+// single transaction so the current slot is kept when the moved one is taken,
+// both resources are locked in ID order to avoid deadlock with a reschedule the other way.
+// It return errStatusChanged if the booking was changed since current was read
+func (r *repository) RescheduleBooking(ctx context.Context, current Booking, moved Booking, buffer time.Duration, transition *Transition) error {
+	resourceIDs := []int{current.ResourceID}
+	if moved.ResourceID < current.ResourceID {
+		resourceIDs = []int{moved.ResourceID, current.ResourceID}
+	} else if moved.ResourceID > current.ResourceID {
+		resourceIDs = append(resourceIDs, moved.ResourceID)
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		for _, id := range resourceIDs {
+			if err := lockResource(tx, id); err != nil {
+				return err
+			}
+		}
+
+		err := checkOverlap(tx, moved.ResourceID, moved.StartAt.Add(-buffer), moved.EndAt.Add(buffer), time.Now(), current.ID, 0)
+		if err != nil {
+			return err
+		}
+		result := tx.Model(&Booking{}).
+			Where("id = ? AND status = ? AND resource_id = ? AND start_at = ? AND end_at = ?",
+				current.ID, current.Status, current.ResourceID, current.StartAt, current.EndAt).
+			Updates(map[string]interface{}{
+				"status":          moved.Status,
+				"resource_id":     moved.ResourceID,
+				"start_at":        moved.StartAt,
+				"end_at":          moved.EndAt,
+				"party_size":      moved.PartySize,
+				"price":           moved.Price,
+				"currency":        moved.Currency,
+				"reschedule_fees": moved.RescheduleFees,
+			})
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errStatusChanged
+		}
+		return tx.Create(transition).Error
+	})
+}
+
+// TransitionBooking move the booking from FromStatus to ToStatus, save the other fields
+// and record the transition, it return errStatusChanged if the booking is not in FromStatus
+func (r *repository) TransitionBooking(ctx context.Context, transition *Transition, fields map[string]interface{}) error {
+	updates := map[string]interface{}{"status": transition.ToStatus}
+	for k, v := range fields {
+		updates[k] = v
+	}
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		result := tx.Model(&Booking{}).
+			Where("id = ? AND status = ?", transition.BookingID, transition.FromStatus).
+			Updates(updates)
+		if result.Error != nil {
+			return result.Error
+		}
+		if result.RowsAffected == 0 {
+			return errStatusChanged
+		}
+		return tx.Create(transition).Error
+	})
+}
+
+func (r *repository) ListTransitions(ctx context.Context, bookingID int) ([]Transition, error) {
+	var transitions []Transition
+	err := r.db.WithContext(ctx).
+		Where("booking_id = ?", bookingID).
+		Order("id").
+		Find(&transitions).Error
+	return transitions, err
+}
+
+// CreateHold insert the hold with the same guarantee as CreateBooking, unless the
+// user already has maxActive active holds at the venue. The venue row is locked
+// before the resource row so concurrent holds of one user can not exceed the limit
+func (r *repository) CreateHold(ctx context.Context, hold *Hold, buffer time.Duration, maxActive int) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := lockVenue(tx, hold.VenueID); err != nil {
+			return err
+		}
+		if err := lockResource(tx, hold.ResourceID); err != nil {
+			return err
+		}
+		now := time.Now()
+		var count int64
+		err := tx.Model(&Hold{}).
+			Where("venue_id = ? AND user_id = ?", hold.VenueID, hold.UserID).
+			Where("booking_id IS NULL AND released_at IS NULL AND expires_at > ?", now).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count >= int64(maxActive) {
+			return errTooManyHolds
+		}
+		if err := checkOverlap(tx, hold.ResourceID, hold.StartAt.Add(-buffer), hold.EndAt.Add(buffer), now, 0, 0); err != nil {
+			return err
+		}
+		return tx.Create(hold).Error
+	})
+}
+
+func (r *repository) GetHold(ctx context.Context, id int) (Hold, error) {
+	var hold Hold
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&hold).Error
+	return hold, err
+}
+
+// ReleaseHold return gorm.ErrRecordNotFound if the hold is not active
+func (r *repository) ReleaseHold(ctx context.Context, id int, now time.Time) error {
+	result := r.db.WithContext(ctx).Model(&Hold{}).
+		Where("id = ? AND booking_id IS NULL AND released_at IS NULL AND expires_at > ?", id, now).
+		Update("released_at", now)
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
+// ListActiveHolds return holds not expired at now which overlap [from, to)
+func (r *repository) ListActiveHolds(ctx context.Context, resourceIDs []int, from, to, now time.Time) ([]Hold, error) {
+	var holds []Hold
+	err := r.db.WithContext(ctx).
+		Select("id, resource_id, start_at, end_at").
+		Where("resource_id IN ? AND start_at < ? AND end_at > ?", resourceIDs, to, from).
+		Where("booking_id IS NULL AND released_at IS NULL AND expires_at > ?", now).
+		Find(&holds).Error
+	return holds, err
+}
+
+func (r *repository) SetHoldKey(ctx context.Context, id int, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SET", fmt.Sprintf(keyHold, id), 1, "PX", ttl.Milliseconds())
+	return err
+}
+
+func (r *repository) HoldKeyExists(ctx context.Context, id int) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("EXISTS", fmt.Sprintf(keyHold, id)))
+}
+
+func (r *repository) DeleteHoldKey(ctx context.Context, id int) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("DEL", fmt.Sprintf(keyHold, id))
+	return err
+}
+
+// GetResourceVersions return the version of every resource, zero when it was never changed
+func (r *repository) GetResourceVersions(ctx context.Context, resourceIDs []int) ([]int64, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	args := make([]interface{}, 0, len(resourceIDs))
+	for _, id := range resourceIDs {
+		args = append(args, fmt.Sprintf(keyResourceVersion, id))
+	}
+	values, err := redis.Values(conn.Do("MGET", args...))
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]int64, len(values))
+	for i, v := range values {
+		if v == nil {
+			continue
+		}
+		if versions[i], err = redis.Int64(v, nil); err != nil {
+			return nil, err
+		}
+	}
+	return versions, nil
+}
+
+func (r *repository) IncrResourceVersion(ctx context.Context, resourceID int) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("INCR", fmt.Sprintf(keyResourceVersion, resourceID))
+	return err
+}
+
+// lockVenue lock the venue row until the end of the transaction, it is only
+// taken by CreateHold and always before the resource row
+func lockVenue(tx *gorm.DB, venueID int) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Take(&venue.Venue{}, venueID).Error
+}
+
+// lockResource lock the resource row until the end of the transaction
+func lockResource(tx *gorm.DB, resourceID int) error {
+	return tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+		Select("id").
+		Take(&resource.Resource{}, resourceID).Error
+}
+
+// checkOverlap return errOverlap if an occupying booking other than exceptBookingID
+// or active hold other than exceptHoldID overlap [from, to)
+func checkOverlap(tx *gorm.DB, resourceID int, from, to, now time.Time, exceptBookingID, exceptHoldID int) error {
+	var count int64
+	err := tx.Model(&Booking{}).
+		Where("resource_id = ? AND start_at < ? AND end_at > ? AND status IN ?", resourceID, to, from, occupyingStatuses).
+		Where("id <> ?", exceptBookingID).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errOverlap
+	}
+
+	err = tx.Model(&Hold{}).
+		Where("resource_id = ? AND start_at < ? AND end_at > ? AND id <> ?", resourceID, to, from, exceptHoldID).
+		Where("booking_id IS NULL AND released_at IS NULL AND expires_at > ?", now).
+		Count(&count).Error
+	if err != nil {
+		return err
+	}
+	if count > 0 {
+		return errOverlap
+	}
+	return nil
+}
+
+// overlapAny check whether the booking is closer than buffer to one of the bookings
+func overlapAny(bookings []*Booking, booking *Booking, buffer time.Duration) bool {
+	for _, b := range bookings {
+		if b.StartAt.Before(booking.EndAt.Add(buffer)) && b.EndAt.After(booking.StartAt.Add(-buffer)) {
+			return true
+		}
+	}
+	return false
+}
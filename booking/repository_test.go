@@ -0,0 +1,356 @@
+package booking
+
+import (
+	"context"
+	"database/sql/driver"
+	"errors"
+	"os"
+	"regexp"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/DATA-DOG/go-sqlmock"
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+	"gorm.io/driver/mysql"
+	"gorm.io/gorm"
+	gormLogger "gorm.io/gorm/logger"
+	"gorm.io/gorm/schema"
+)
+
+var testGormConfig = &gorm.Config{
+	Logger: gormLogger.Default.LogMode(gormLogger.Silent),
+	NamingStrategy: schema.NamingStrategy{
+		SingularTable: true,
+	},
+}
+
+// openTestDB connect to the MySQL database of TEST_MYSQL_DSN and migrate the booking
+// tables, e.g. TEST_MYSQL_DSN="root:secret@tcp(localhost:3306)/booking_test?parseTime=true&loc=UTC".
+// The test is skipped without it since the row locks cannot be checked on a fake
+func openTestDB(t *testing.T) *gorm.DB {
+	t.Helper()
+	dsn := os.Getenv("TEST_MYSQL_DSN")
+	if dsn == "" {
+		t.Skip("TEST_MYSQL_DSN is not set")
+	}
+	db, err := gorm.Open(mysql.Open(dsn), testGormConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	sqlDB, err := db.DB()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() { sqlDB.Close() })
+
+	err = db.AutoMigrate(&resource.Resource{}, &Booking{}, &Transition{}, &Hold{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db
+}
+
+// openMockDB return a database on sqlmock which expect the statements in the order
+// they are declared, the expectations are checked at the end of the test
+func openMockDB(t *testing.T) (*gorm.DB, sqlmock.Sqlmock) {
+	t.Helper()
+	sqlDB, mock, err := sqlmock.New()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(func() {
+		if err := mock.ExpectationsWereMet(); err != nil {
+			t.Error(err)
+		}
+		sqlDB.Close()
+	})
+	db, err := gorm.Open(mysql.New(mysql.Config{Conn: sqlDB, SkipInitializeWithVersion: true}), testGormConfig)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return db, mock
+}
+
+// createTestResource insert a new resource so every run books on free rows
+func createTestResource(t *testing.T, db *gorm.DB, r resource.Resource) resource.Resource {
+	t.Helper()
+	if err := db.Create(&r).Error; err != nil {
+		t.Fatal(err)
+	}
+	return r
+}
+
+// TestCreateBookingLocksResource check the statements of the booking transaction
+// without MySQL: the resource row is locked before the bookings and holds are
+// counted, so a concurrent transaction cannot insert between the count and the insert
+func TestCreateBookingLocksResource(t *testing.T) {
+	const buffer = 15 * time.Minute
+	start := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+	newBooking := func() Booking {
+		return Booking{VenueID: 1, ResourceID: 7, UserID: 10, StartAt: start, EndAt: start.Add(time.Hour), PartySize: 2, Status: StatusConfirmed}
+	}
+
+	lockResource := func(mock sqlmock.Sqlmock) *sqlmock.ExpectedQuery {
+		return mock.ExpectQuery("SELECT `id` FROM `resource` WHERE `resource`.`id` = \\? .* FOR UPDATE$").WithArgs(7)
+	}
+	// the overlap is counted on the interval widened by the buffer
+	countArgs := []driver.Value{7, start.Add(time.Hour + buffer), start.Add(-buffer)}
+	for _, s := range occupyingStatuses {
+		countArgs = append(countArgs, string(s))
+	}
+	// a new booking has no id to exclude
+	countArgs = append(countArgs, 0)
+	countBookings := func(mock sqlmock.Sqlmock, count int) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(1) FROM `booking`")).
+			WithArgs(countArgs...).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+	countHolds := func(mock sqlmock.Sqlmock, count int) {
+		mock.ExpectQuery(regexp.QuoteMeta("SELECT count(1) FROM `booking_hold`")).
+			WithArgs(7, start.Add(time.Hour+buffer), start.Add(-buffer), 0, sqlmock.AnyArg()).
+			WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(count))
+	}
+
+	tests := []struct {
+		name   string
+		expect func(mock sqlmock.Sqlmock)
+		want   error
+	}{
+		{
+			name: "free",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockResource(mock).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				countBookings(mock, 0)
+				countHolds(mock, 0)
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `booking`")).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `booking_transition`")).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			},
+		},
+		{
+			name: "overlap",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockResource(mock).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				countBookings(mock, 1)
+				mock.ExpectRollback()
+			},
+			want: errOverlap,
+		},
+		{
+			name: "held",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockResource(mock).WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+				countBookings(mock, 0)
+				countHolds(mock, 1)
+				mock.ExpectRollback()
+			},
+			want: errOverlap,
+		},
+		{
+			name: "resource deleted",
+			expect: func(mock sqlmock.Sqlmock) {
+				mock.ExpectBegin()
+				lockResource(mock).WillReturnRows(sqlmock.NewRows([]string{"id"}))
+				mock.ExpectRollback()
+			},
+			want: gorm.ErrRecordNotFound,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openMockDB(t)
+			tt.expect(mock)
+
+			booking := newBooking()
+			err := NewRepository(db, nil).CreateBooking(context.Background(), &booking, buffer, 0)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+			if tt.want == nil && booking.ID != 1 {
+				t.Errorf("booking id = %d, want the inserted id", booking.ID)
+			}
+		})
+	}
+}
+
+// TestCreateHoldLimit check the active holds of the user are counted once the venue
+// and the resource rows are locked, so concurrent holds can not exceed the limit
+func TestCreateHoldLimit(t *testing.T) {
+	const buffer = 15 * time.Minute
+	start := time.Date(2026, 6, 1, 10, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name   string
+		active int
+		want   error
+	}{
+		{"under the limit", 2, nil},
+		{"at the limit", 3, errTooManyHolds},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db, mock := openMockDB(t)
+			mock.ExpectBegin()
+			mock.ExpectQuery("SELECT `id` FROM `venue` WHERE `venue`.`id` = \\? .*FOR UPDATE$").WithArgs(1).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(1))
+			mock.ExpectQuery("SELECT `id` FROM `resource` WHERE `resource`.`id` = \\? .* FOR UPDATE$").WithArgs(7).
+				WillReturnRows(sqlmock.NewRows([]string{"id"}).AddRow(7))
+			mock.ExpectQuery(regexp.QuoteMeta("SELECT count(1) FROM `booking_hold` WHERE (venue_id = ? AND user_id = ?)")).
+				WithArgs(1, 10, sqlmock.AnyArg()).
+				WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(tt.active))
+			if tt.want == nil {
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT count(1) FROM `booking`")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectQuery(regexp.QuoteMeta("SELECT count(1) FROM `booking_hold`")).
+					WillReturnRows(sqlmock.NewRows([]string{"count"}).AddRow(0))
+				mock.ExpectExec(regexp.QuoteMeta("INSERT INTO `booking_hold`")).WillReturnResult(sqlmock.NewResult(1, 1))
+				mock.ExpectCommit()
+			} else {
+				mock.ExpectRollback()
+			}
+
+			hold := Hold{VenueID: 1, ResourceID: 7, UserID: 10, StartAt: start, EndAt: start.Add(time.Hour), PartySize: 2, ExpiresAt: start}
+			err := NewRepository(db, nil).CreateHold(context.Background(), &hold, buffer, 3)
+			if !errors.Is(err, tt.want) {
+				t.Fatalf("got error %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+// TestCreateBookingConcurrent book the same slot, or a slot inside its buffer,
+// from many goroutines at once, the resource lock must let only one of them in
+func TestCreateBookingConcurrent(t *testing.T) {
+	const workers = 20
+	ctx := context.Background()
+	db := openTestDB(t)
+
+	v := venue.Venue{ID: 1, Timezone: "UTC", OpeningHours: openEveryDay(8*60, 22*60)}
+	r := createTestResource(t, db, resource.Resource{
+		VenueID:     v.ID,
+		Name:        "court",
+		Type:        "court",
+		Capacity:    4,
+		BufferAfter: 15,
+		Active:      true,
+	})
+	s := newTestService(NewRepository(db, newTestPool(t)), testVenueRepository{venue: v}, r)
+
+	day := time.Now().UTC().AddDate(0, 0, 1)
+	slot := time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.UTC)
+	requests := []CreateBooking{
+		{ResourceID: r.ID, StartAt: slot, EndAt: slot.Add(time.Hour), PartySize: 2},
+		// starts 5 minutes after the slot, inside the 15 minutes buffer
+		{ResourceID: r.ID, StartAt: slot.Add(65 * time.Minute), EndAt: slot.Add(125 * time.Minute), PartySize: 2},
+	}
+
+	var wg sync.WaitGroup
+	errs := make([]error, workers)
+	start := make(chan struct{})
+	for i := 0; i < workers; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			<-start
+			_, errs[i] = s.CreateBooking(ctx, server.AuthInfo{UserID: 100 + i}, requests[i%len(requests)])
+		}(i)
+	}
+	close(start)
+	wg.Wait()
+
+	booked, unavailable := 0, 0
+	for i, err := range errs {
+		switch {
+		case err == nil:
+			booked++
+		case errors.Is(err, ErrSlotUnavailable):
+			unavailable++
+		default:
+			t.Errorf("worker %d: unexpected error %v", i, err)
+		}
+	}
+	if booked != 1 || unavailable != workers-1 {
+		t.Errorf("booked = %d, unavailable = %d, want 1 and %d", booked, unavailable, workers-1)
+	}
+
+	var count int64
+	if err := db.Model(&Booking{}).Where("resource_id = ?", r.ID).Count(&count).Error; err != nil {
+		t.Fatal(err)
+	}
+	if count != 1 {
+		t.Errorf("%d bookings stored, want 1", count)
+	}
+}
+
+// TestCancelSeriesBookingsRollback cancel occurrences read before one of them was
+// cancelled elsewhere, the transaction must leave every occurrence untouched
+func TestCancelSeriesBookingsRollback(t *testing.T) {
+	ctx := context.Background()
+	db := openTestDB(t)
+	repo := NewRepository(db, newTestPool(t))
+	r := createTestResource(t, db, resource.Resource{VenueID: 1, Name: "room", Type: "room", Capacity: 4, Active: true})
+
+	start := time.Now().UTC().AddDate(0, 0, 1).Truncate(time.Hour)
+	series := Series{VenueID: 1, ResourceID: r.ID, UserID: 10, RRule: "FREQ=WEEKLY;COUNT=3", StartAt: start, EndAt: start.Add(time.Hour), PartySize: 2}
+	var bookings []*Booking
+	for i := 0; i < 3; i++ {
+		startAt := start.AddDate(0, 0, 7*i)
+		bookings = append(bookings, &Booking{VenueID: 1, ResourceID: r.ID, UserID: 10, StartAt: startAt, EndAt: startAt.Add(time.Hour), PartySize: 2, Status: StatusConfirmed, Price: 1000})
+	}
+	conflicts, err := repo.CreateSeries(ctx, &series, bookings, 0, false)
+	if err != nil || len(conflicts) > 0 {
+		t.Fatalf("series not created, conflicts %v, %v", conflicts, err)
+	}
+
+	var cancellations []Cancellation
+	for _, b := range bookings {
+		cancellations = append(cancellations, Cancellation{Booking: *b, Outcome: policy.FullRefund(b.Price)})
+	}
+	// the venue cancel the last occurrence after it was read
+	err = db.Model(&Booking{}).Where("id = ?", bookings[2].ID).Update("status", StatusCancelled).Error
+	if err != nil {
+		t.Fatal(err)
+	}
+	err = repo.CancelSeriesBookings(ctx, series.ID, cancellations, 10, "")
+	if !errors.Is(err, errStatusChanged) {
+		t.Fatalf("got error %v, want %v", err, errStatusChanged)
+	}
+	for _, b := range bookings[:2] {
+		stored, err := repo.GetBooking(ctx, b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != StatusConfirmed || stored.RefundAmount != 0 {
+			t.Errorf("booking %d is %s with refund %d after the rollback", b.ID, stored.Status, stored.RefundAmount)
+		}
+		transitions, err := repo.ListTransitions(ctx, b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(transitions) != 1 {
+			t.Errorf("booking %d has %d transitions, want only the creation", b.ID, len(transitions))
+		}
+	}
+
+	// the occurrences still in the status they were read with are cancelled together
+	err = repo.CancelSeriesBookings(ctx, series.ID, cancellations[:2], 10, "")
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, b := range bookings[:2] {
+		stored, err := repo.GetBooking(ctx, b.ID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if stored.Status != StatusCancelled || stored.RefundAmount != b.Price {
+			t.Errorf("booking %d is %s with refund %d, want cancelled with refund %d", b.ID, stored.Status, stored.RefundAmount, b.Price)
+		}
+	}
+}
@@ -0,0 +1,120 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+	"gorm.io/gorm"
+)
+
+// rescheduleRule allow the customer to move the booking before it start and the venue at any time
+var rescheduleRule = transitionRule{from: []Status{StatusPending, StatusConfirmed}, customer: true}
+
+// RescheduleBooking move the booking to another slot of the same venue, the booking
+// keep its current slot when the new one is not available. The customer pay the
+// reschedule fee of the policy of the current resource, the venue move it for free.
+// Moved to another resource, the booking is pending again if that resource require confirmation
+func (s *service) RescheduleBooking(ctx context.Context, actor server.AuthInfo, id int, req RescheduleBooking) (Booking, Reschedule, error) {
+	req.Reason = strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(req.Reason) > maxReasonLength {
+		return Booking{}, Reschedule{}, ErrInvalidReason
+	}
+	current, err := s.repo.GetBooking(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Booking{}, Reschedule{}, ErrBookingNotFound
+	}
+	if err != nil {
+		return Booking{}, Reschedule{}, internalError(err)
+	}
+	byCustomer, err := s.authorizeTransition(ctx, actor, current, rescheduleRule)
+	if err != nil {
+		return Booking{}, Reschedule{}, err
+	}
+	if !statusIn(current.Status, rescheduleRule.from) {
+		return Booking{}, Reschedule{}, invalidTransition(current.Status, "rescheduled")
+	}
+
+	if req.ResourceID == 0 {
+		req.ResourceID = current.ResourceID
+	}
+	if req.PartySize == 0 {
+		req.PartySize = current.PartySize
+	}
+	if req.ResourceID == current.ResourceID && req.PartySize == current.PartySize &&
+		req.StartAt.Equal(current.StartAt) && req.EndAt.Equal(current.EndAt) {
+		return Booking{}, Reschedule{}, ErrSameSlot
+	}
+	r, v, err := s.checkSlot(ctx, req.ResourceID, req.StartAt, req.EndAt, req.PartySize)
+	if err != nil {
+		return Booking{}, Reschedule{}, err
+	}
+	if v.ID != current.VenueID {
+		return Booking{}, Reschedule{}, ErrOtherVenue
+	}
+	if current.Currency != "" && r.Currency != "" && current.Currency != r.Currency {
+		return Booking{}, Reschedule{}, ErrCurrencyMismatch
+	}
+
+	fee := policy.Outcome{Rule: policy.RuleRescheduledByVenue}
+	if byCustomer {
+		p, err := s.resourcePolicy(ctx, current.ResourceID)
+		if err != nil {
+			return Booking{}, Reschedule{}, err
+		}
+		fee = policy.EvaluateReschedule(p, current.Price, current.StartAt, time.Now())
+	}
+
+	moved := current
+	moved.ResourceID = r.ID
+	moved.StartAt = req.StartAt.UTC()
+	moved.EndAt = req.EndAt.UTC()
+	moved.PartySize = req.PartySize
+	moved.Price = r.Price(req.EndAt.Sub(req.StartAt))
+	moved.Currency = r.Currency
+	moved.RescheduleFees += fee.Fee
+	// the new resource may or may not require the venue to confirm the booking
+	if moved.ResourceID != current.ResourceID {
+		moved.Status = initialStatus(r)
+	}
+
+	reason := fmt.Sprintf("rescheduled from resource %d at %s", current.ResourceID, current.StartAt.UTC().Format(time.RFC3339))
+	if req.Reason != "" {
+		reason += ": " + req.Reason
+	}
+	err = s.repo.RescheduleBooking(ctx, current, moved, r.Buffer(), &Transition{
+		BookingID:  current.ID,
+		FromStatus: current.Status,
+		ToStatus:   moved.Status,
+		ActorID:    actor.UserID,
+		Reason:     reason,
+	})
+	if errors.Is(err, errOverlap) {
+		return Booking{}, Reschedule{}, ErrSlotUnavailable
+	}
+	if errors.Is(err, errStatusChanged) {
+		return Booking{}, Reschedule{}, ErrStatusChanged
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Booking{}, Reschedule{}, resource.ErrResourceNotFound
+	}
+	if err != nil {
+		return Booking{}, Reschedule{}, internalError(err)
+	}
+
+	s.resourceChanged(ctx, current.ResourceID)
+	if moved.ResourceID != current.ResourceID {
+		s.resourceChanged(ctx, moved.ResourceID)
+	}
+	return moved, Reschedule{
+		OldPrice: current.Price,
+		NewPrice: moved.Price,
+		Fee:      fee,
+	}, nil
+}
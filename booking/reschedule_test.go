@@ -0,0 +1,151 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+)
+
+func TestRescheduleBooking(t *testing.T) {
+	ctx := context.Background()
+	const (
+		customerID = 10
+		ownerID    = 20
+		policyID   = 7
+	)
+	v := venue.Venue{ID: 1, OwnerID: ownerID, Timezone: "UTC", OpeningHours: openEveryDay(0, 24*60)}
+	policyIDRef := policyID
+	court := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true, HourlyRate: 6000, Currency: "EUR", CancellationPolicyID: &policyIDRef}
+	// suite needs the venue to confirm every booking
+	suite := resource.Resource{ID: 2, VenueID: v.ID, Capacity: 4, Active: true, HourlyRate: 9000, Currency: "EUR", RequiresConfirmation: true}
+
+	// the booking starts within the 24 hours of the policy, moving it cost 10% of its price
+	start := time.Now().UTC().Truncate(time.Hour).Add(3 * time.Hour)
+	booking := Booking{
+		ID:         1,
+		VenueID:    v.ID,
+		ResourceID: court.ID,
+		UserID:     customerID,
+		StartAt:    start,
+		EndAt:      start.Add(time.Hour),
+		PartySize:  2,
+		Status:     StatusConfirmed,
+		Price:      6000,
+		Currency:   "EUR",
+	}
+	// other is booked right after booking on the court
+	other := Booking{
+		ID:         2,
+		VenueID:    v.ID,
+		ResourceID: court.ID,
+		UserID:     11,
+		StartAt:    start.Add(2 * time.Hour),
+		EndAt:      start.Add(3 * time.Hour),
+		PartySize:  2,
+		Status:     StatusConfirmed,
+		Price:      6000,
+		Currency:   "EUR",
+	}
+	customer := server.AuthInfo{UserID: customerID, Role: "customer", Permissions: []string{server.PermissionBookingCreate}}
+	owner := server.AuthInfo{UserID: ownerID, Role: "venue_admin", Permissions: []string{"booking:create", server.PermissionBookingManage}}
+	lateFee := policy.Outcome{PolicyID: policyID, Rule: policy.RuleLateFee, FeePercent: 10, Fee: 600, FreeUntil: timePtr(start.Add(-24 * time.Hour))}
+
+	tests := []struct {
+		name  string
+		actor server.AuthInfo
+		req   RescheduleBooking
+		err   error
+		// want is the stored booking and price change when err is nil
+		want       Booking
+		reschedule Reschedule
+	}{
+		{
+			name:  "taken slot",
+			actor: customer,
+			req:   RescheduleBooking{StartAt: other.StartAt.Add(30 * time.Minute), EndAt: other.EndAt.Add(30 * time.Minute)},
+			err:   ErrSlotUnavailable,
+		},
+		{
+			name:  "overlapping its own slot",
+			actor: customer,
+			req:   RescheduleBooking{StartAt: start.Add(30 * time.Minute), EndAt: start.Add(90 * time.Minute)},
+			want: withSlot(booking, func(b *Booking) {
+				b.StartAt = start.Add(30 * time.Minute)
+				b.EndAt = start.Add(90 * time.Minute)
+				b.RescheduleFees = 600
+			}),
+			reschedule: Reschedule{OldPrice: 6000, NewPrice: 6000, Fee: lateFee},
+		},
+		{
+			name:  "pricier resource requiring confirmation",
+			actor: customer,
+			req:   RescheduleBooking{ResourceID: suite.ID, StartAt: start, EndAt: start.Add(time.Hour)},
+			want: withSlot(booking, func(b *Booking) {
+				b.ResourceID = suite.ID
+				b.Status = StatusPending
+				b.Price = 9000
+				b.RescheduleFees = 600
+			}),
+			reschedule: Reschedule{OldPrice: 6000, NewPrice: 9000, Fee: lateFee},
+		},
+		{
+			name:  "moved by the venue",
+			actor: owner,
+			req:   RescheduleBooking{StartAt: start.Add(4 * time.Hour), EndAt: start.Add(6 * time.Hour)},
+			want: withSlot(booking, func(b *Booking) {
+				b.StartAt = start.Add(4 * time.Hour)
+				b.EndAt = start.Add(6 * time.Hour)
+				b.Price = 12000
+			}),
+			reschedule: Reschedule{OldPrice: 6000, NewPrice: 12000, Fee: policy.Outcome{Rule: policy.RuleRescheduledByVenue}},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			repo := newTestRepository(booking, other)
+			s := newTestService(repo, testVenueRepository{venue: v}, court, suite)
+			s.policies = testPolicies{policy: policy.Policy{ID: policyID, VenueID: v.ID, FreeCancellationHours: 24, LateFeePercent: 50, RescheduleFeePercent: 10}}
+
+			moved, reschedule, err := s.RescheduleBooking(ctx, tt.actor, booking.ID, tt.req)
+			stored, _ := repo.GetBooking(ctx, booking.ID)
+			if tt.err != nil {
+				if !errors.Is(err, tt.err) {
+					t.Fatalf("got error %v, want %v", err, tt.err)
+				}
+				if !reflect.DeepEqual(stored, booking) || len(repo.transitions) != 0 {
+					t.Errorf("booking changed to %+v by a rejected reschedule", stored)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !reflect.DeepEqual(moved, tt.want) || !reflect.DeepEqual(stored, tt.want) {
+				t.Errorf("moved = %+v, stored = %+v, want %+v", moved, stored, tt.want)
+			}
+			if !reflect.DeepEqual(reschedule, tt.reschedule) {
+				t.Errorf("reschedule = %+v, want %+v", reschedule, tt.reschedule)
+			}
+			if len(repo.transitions) != 1 || repo.transitions[0].FromStatus != booking.Status || repo.transitions[0].ToStatus != tt.want.Status {
+				t.Errorf("transitions = %+v, want one from %s to %s", repo.transitions, booking.Status, tt.want.Status)
+			}
+		})
+	}
+}
+
+// withSlot return a copy of the booking changed by change
+func withSlot(b Booking, change func(b *Booking)) Booking {
+	change(&b)
+	return b
+}
+
+func timePtr(t time.Time) *time.Time {
+	return &t
+}
@@ -0,0 +1,295 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/schedule"
+	"github.com/booking-man-be/venue"
+	"github.com/teambition/rrule-go"
+	"gorm.io/gorm"
+)
+
+const (
+	maxOccurrences = 52
+	maxRRuleLength = 500
+)
+
+// CreateSeries book every occurrence of the recurrence rule, the first occurrence
+// is StartAt to EndAt and the next ones are at the same time of the venue clock.
+// Every occurrence is returned with its booking or the reason it cannot be booked,
+// the series is not created when nothing is booked
+func (s *service) CreateSeries(ctx context.Context, actor server.AuthInfo, req CreateSeries) (Series, []Occurrence, error) {
+	if !req.EndAt.After(req.StartAt) || req.EndAt.Sub(req.StartAt) > maxBookingDuration {
+		return Series{}, nil, ErrInvalidInterval
+	}
+	r, v, err := s.getBookableResource(ctx, req.ResourceID)
+	if err != nil {
+		return Series{}, nil, err
+	}
+	if req.PartySize < 1 || req.PartySize > r.Capacity {
+		return Series{}, nil, ErrInvalidPartySize
+	}
+	loc, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return Series{}, nil, internalError(err)
+	}
+	rule := strings.TrimPrefix(strings.ToUpper(strings.TrimSpace(req.RRule)), "RRULE:")
+	starts, err := expandRRule(rule, req.StartAt.In(loc))
+	if err != nil {
+		return Series{}, nil, err
+	}
+
+	duration := req.EndAt.Sub(req.StartAt)
+	occurrences := make([]Occurrence, 0, len(starts))
+	for _, start := range starts {
+		occurrences = append(occurrences, Occurrence{StartAt: start.UTC(), EndAt: start.Add(duration).UTC()})
+	}
+	if err := s.findConflicts(ctx, v, r, loc, occurrences); err != nil {
+		return Series{}, nil, err
+	}
+
+	series := Series{
+		VenueID:    v.ID,
+		ResourceID: r.ID,
+		UserID:     actor.UserID,
+		RRule:      rule,
+		StartAt:    req.StartAt.UTC(),
+		EndAt:      req.EndAt.UTC(),
+		PartySize:  req.PartySize,
+	}
+	var bookings []*Booking
+	var booked []int
+	for i, o := range occurrences {
+		if o.Conflict != "" {
+			continue
+		}
+		bookings = append(bookings, &Booking{
+			VenueID:    v.ID,
+			ResourceID: r.ID,
+			UserID:     actor.UserID,
+			StartAt:    o.StartAt,
+			EndAt:      o.EndAt,
+			PartySize:  req.PartySize,
+			Status:     initialStatus(r),
+			Price:      r.Price(duration),
+			Currency:   r.Currency,
+		})
+		booked = append(booked, i)
+	}
+	if len(bookings) == 0 || (len(bookings) < len(occurrences) && !req.AcceptPartial) {
+		return Series{}, occurrences, nil
+	}
+
+	conflicts, err := s.repo.CreateSeries(ctx, &series, bookings, r.Buffer(), req.AcceptPartial)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Series{}, nil, resource.ErrResourceNotFound
+	}
+	if err != nil {
+		return Series{}, nil, internalError(err)
+	}
+	// taken between the check and the lock
+	for _, i := range conflicts {
+		occurrences[booked[i]].Conflict = ConflictUnavailable
+	}
+	if series.ID == 0 {
+		return Series{}, occurrences, nil
+	}
+
+	for i, b := range bookings {
+		occurrences[booked[i]].BookingID = b.ID
+	}
+	s.resourceChanged(ctx, r.ID)
+	return series, occurrences, nil
+}
+
+// GetSeries return the series and its bookings to its customer or the manager and staff of the venue
+func (s *service) GetSeries(ctx context.Context, actor server.AuthInfo, id int) (Series, []Booking, error) {
+	series, err := s.repo.GetSeries(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Series{}, nil, ErrSeriesNotFound
+	}
+	if err != nil {
+		return Series{}, nil, internalError(err)
+	}
+	if series.UserID != actor.UserID {
+		_, err := s.venues.GetStaffedVenue(ctx, actor, series.VenueID)
+		if errors.Is(err, venue.ErrNotVenueManager) || errors.Is(err, venue.ErrVenueNotFound) {
+			return Series{}, nil, ErrSeriesNotFound
+		}
+		if err != nil {
+			return Series{}, nil, err
+		}
+	}
+
+	bookings, err := s.repo.ListSeriesBookings(ctx, id, time.Time{})
+	if err != nil {
+		return Series{}, nil, internalError(err)
+	}
+	return series, bookings, nil
+}
+
+// CancelSeriesBooking cancel the booking, and with following or all scope the
+// other occurrences of its series which have not started and can be cancelled,
+// each one is refunded according to the cancellation policy. The occurrences are
+// cancelled together, none is cancelled when one of them changed meanwhile
+func (s *service) CancelSeriesBooking(ctx context.Context, actor server.AuthInfo, id int, scope CancelScope, reason string) ([]Cancellation, error) {
+	switch scope {
+	case CancelScopeOne:
+		booking, outcome, err := s.CancelBooking(ctx, actor, id, reason)
+		if err != nil {
+			return nil, err
+		}
+		return []Cancellation{{Booking: booking, Outcome: outcome}}, nil
+	case CancelScopeFollowing, CancelScopeAll:
+	default:
+		return nil, ErrInvalidCancelScope
+	}
+	reason = strings.TrimSpace(reason)
+	if utf8.RuneCountInString(reason) > maxReasonLength {
+		return nil, ErrInvalidReason
+	}
+
+	booking, err := s.GetBooking(ctx, actor, id)
+	if err != nil {
+		return nil, err
+	}
+	if booking.SeriesID == nil {
+		return nil, ErrNotInSeries
+	}
+	var from time.Time
+	if scope == CancelScopeFollowing {
+		from = booking.StartAt
+	}
+	bookings, err := s.repo.ListSeriesBookings(ctx, *booking.SeriesID, from)
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	now := time.Now()
+	rule := transitionRules[StatusCancelled]
+	var cancellations []Cancellation
+	for _, b := range bookings {
+		if statusIn(b.Status, rule.from) && b.StartAt.After(now) {
+			cancellations = append(cancellations, Cancellation{Booking: b})
+		}
+	}
+	if len(cancellations) == 0 {
+		return nil, nil
+	}
+	// the occurrences have the same customer and venue
+	byCustomer, err := s.authorizeTransition(ctx, actor, cancellations[0].Booking, rule)
+	if err != nil {
+		return nil, err
+	}
+	for i, c := range cancellations {
+		if cancellations[i].Outcome, err = s.cancellationOutcome(ctx, c.Booking, byCustomer, now); err != nil {
+			return nil, err
+		}
+	}
+
+	err = s.repo.CancelSeriesBookings(ctx, *booking.SeriesID, cancellations, actor.UserID, reason)
+	if errors.Is(err, errStatusChanged) {
+		return nil, ErrStatusChanged
+	}
+	if err != nil {
+		return nil, internalError(err)
+	}
+	changed := map[int]bool{}
+	for i, c := range cancellations {
+		cancellations[i].Booking.Status = StatusCancelled
+		cancellations[i].Booking.CancellationFee = c.Outcome.Fee
+		cancellations[i].Booking.RefundAmount = c.Outcome.Refund
+		// an occurrence may have been moved to another resource
+		if !changed[c.Booking.ResourceID] {
+			changed[c.Booking.ResourceID] = true
+			s.resourceChanged(ctx, c.Booking.ResourceID)
+		}
+	}
+	return cancellations, nil
+}
+
+// findConflicts set the conflict of the occurrences which start in the past, are
+// outside the opening hours or overlap a booking, a hold or an earlier occurrence
+func (s *service) findConflicts(ctx context.Context, v venue.Venue, r resource.Resource, loc *time.Location, occurrences []Occurrence) error {
+	first := occurrences[0].StartAt.In(loc)
+	last := occurrences[len(occurrences)-1].EndAt.Add(-time.Nanosecond).In(loc)
+	startDate := time.Date(first.Year(), first.Month(), first.Day(), 0, 0, 0, 0, loc)
+	endDate := time.Date(last.Year(), last.Month(), last.Day(), 0, 0, 0, 0, loc)
+	schedules, err := s.schedules.GetResourceSchedules(ctx, v, []resource.Resource{r}, startDate, endDate)
+	if err != nil {
+		return err
+	}
+	busy, _, err := s.ListBusyIntervals(ctx, []int{r.ID}, first.Add(-r.Buffer()), last.Add(r.Buffer()))
+	if err != nil {
+		return err
+	}
+
+	now := time.Now()
+	blocked := busy[r.ID]
+	for i, o := range occurrences {
+		interval := schedule.Interval{Start: o.StartAt, End: o.EndAt}
+		switch {
+		case o.StartAt.Before(now):
+			occurrences[i].Conflict = ConflictPast
+		case !schedule.Covers(schedules[r.ID], interval):
+			occurrences[i].Conflict = ConflictClosed
+		case overlapIntervals(blocked, interval, r.Buffer()):
+			occurrences[i].Conflict = ConflictUnavailable
+		default:
+			blocked = append(blocked, interval)
+		}
+	}
+	return nil
+}
+
+// expandRRule return the start of every occurrence, the rule must end with COUNT
+// or UNTIL and have at most maxOccurrences
+func expandRRule(rule string, start time.Time) ([]time.Time, error) {
+	if rule == "" || len(rule) > maxRRuleLength || strings.Contains(rule, "\n") {
+		return nil, ErrInvalidRRule
+	}
+	opt, err := rrule.StrToROptionInLocation(rule, start.Location())
+	if err != nil {
+		return nil, ErrInvalidRRule
+	}
+	if opt.Freq != rrule.DAILY && opt.Freq != rrule.WEEKLY && opt.Freq != rrule.MONTHLY {
+		return nil, ErrInvalidRRule
+	}
+	if opt.Count == 0 && opt.Until.IsZero() {
+		return nil, ErrInvalidRRule
+	}
+	opt.Dtstart = start
+	r, err := rrule.NewRRule(*opt)
+	if err != nil {
+		return nil, ErrInvalidRRule
+	}
+
+	var starts []time.Time
+	next := r.Iterator()
+	for t, ok := next(); ok; t, ok = next() {
+		if len(starts) == maxOccurrences {
+			return nil, ErrTooManyOccurrences
+		}
+		starts = append(starts, t)
+	}
+	if len(starts) == 0 {
+		return nil, ErrInvalidRRule
+	}
+	return starts, nil
+}
+
+// overlapIntervals check whether the interval is closer than buffer to one of the intervals
+func overlapIntervals(intervals []schedule.Interval, interval schedule.Interval, buffer time.Duration) bool {
+	for _, i := range intervals {
+		if i.Start.Before(interval.End.Add(buffer)) && i.End.After(interval.Start.Add(-buffer)) {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,280 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+)
+
+func TestExpandRRule(t *testing.T) {
+	newYork, err := time.LoadLocation("America/New_York")
+	if err != nil {
+		t.Fatal(err)
+	}
+	// Tuesday before the switch to daylight saving time on 2026-03-08
+	start := time.Date(2026, 3, 3, 10, 0, 0, 0, newYork)
+
+	tests := []struct {
+		name  string
+		rule  string
+		want  []string
+		count int
+		err   error
+	}{
+		{
+			name: "count keep the venue clock across DST",
+			rule: "FREQ=WEEKLY;COUNT=3",
+			want: []string{"2026-03-03 10:00 EST", "2026-03-10 10:00 EDT", "2026-03-17 10:00 EDT"},
+		},
+		{
+			name: "until is inclusive",
+			rule: "FREQ=DAILY;UNTIL=20260305T150000Z",
+			want: []string{"2026-03-03 10:00 EST", "2026-03-04 10:00 EST", "2026-03-05 10:00 EST"},
+		},
+		{
+			name: "until in the venue time zone",
+			rule: "FREQ=WEEKLY;UNTIL=20260317T095959",
+			want: []string{"2026-03-03 10:00 EST", "2026-03-10 10:00 EDT"},
+		},
+		{
+			name: "by day",
+			rule: "FREQ=WEEKLY;BYDAY=TU,TH;COUNT=4",
+			want: []string{"2026-03-03 10:00 EST", "2026-03-05 10:00 EST", "2026-03-10 10:00 EDT", "2026-03-12 10:00 EDT"},
+		},
+		{
+			name: "monthly",
+			rule: "FREQ=MONTHLY;COUNT=2",
+			want: []string{"2026-03-03 10:00 EST", "2026-04-03 10:00 EDT"},
+		},
+		{name: "max occurrences", rule: "FREQ=WEEKLY;COUNT=52", count: maxOccurrences},
+		{name: "count over max occurrences", rule: "FREQ=WEEKLY;COUNT=53", err: ErrTooManyOccurrences},
+		{name: "until over max occurrences", rule: "FREQ=DAILY;UNTIL=20270101T000000Z", err: ErrTooManyOccurrences},
+		{name: "no count nor until", rule: "FREQ=WEEKLY;BYDAY=TU", err: ErrInvalidRRule},
+		{name: "until before start", rule: "FREQ=DAILY;UNTIL=20260301T000000Z", err: ErrInvalidRRule},
+		{name: "yearly", rule: "FREQ=YEARLY;COUNT=2", err: ErrInvalidRRule},
+		{name: "hourly", rule: "FREQ=HOURLY;COUNT=2", err: ErrInvalidRRule},
+		{name: "minutely", rule: "FREQ=MINUTELY;COUNT=2", err: ErrInvalidRRule},
+		{name: "empty", rule: "", err: ErrInvalidRRule},
+		{name: "malformed", rule: "FREQ=WEEKLY;COUNT=X", err: ErrInvalidRRule},
+		{name: "several lines", rule: "FREQ=WEEKLY;COUNT=2\nFREQ=DAILY;COUNT=2", err: ErrInvalidRRule},
+		{name: "too long", rule: "FREQ=WEEKLY;COUNT=2;BYDAY=" + strings.Repeat("TU,", 200) + "TU", err: ErrInvalidRRule},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			starts, err := expandRRule(tt.rule, start)
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			if tt.count != 0 && len(starts) != tt.count {
+				t.Errorf("got %d occurrences, want %d", len(starts), tt.count)
+			}
+			if tt.want == nil {
+				return
+			}
+			var got []string
+			for _, s := range starts {
+				got = append(got, s.In(newYork).Format("2006-01-02 15:04 MST"))
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("expandRRule() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestFindConflicts(t *testing.T) {
+	ctx := context.Background()
+	v := venue.Venue{ID: 1, Timezone: "UTC", OpeningHours: openEveryDay(8*60, 22*60)}
+	r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true, BufferAfter: 15}
+
+	now := time.Now().UTC()
+	day := now.AddDate(0, 0, 7)
+	at := func(hour, min int) time.Time {
+		return time.Date(day.Year(), day.Month(), day.Day(), hour, min, 0, 0, time.UTC)
+	}
+	repo := newTestRepository(
+		Booking{ID: 1, VenueID: v.ID, ResourceID: r.ID, StartAt: at(12, 0), EndAt: at(13, 0), Status: StatusConfirmed},
+		Booking{ID: 2, VenueID: v.ID, ResourceID: r.ID, StartAt: at(14, 30), EndAt: at(15, 30), Status: StatusCancelled},
+	)
+	repo.holds = []Hold{{ID: 1, ResourceID: r.ID, StartAt: at(16, 0), EndAt: at(17, 0), ExpiresAt: now.Add(10 * time.Minute)}}
+	s := newTestService(repo, testVenueRepository{venue: v}, r)
+
+	tests := []struct {
+		start, end time.Time
+		want       Conflict
+	}{
+		{now.Add(-time.Hour), now, ConflictPast},
+		{at(7, 0), at(8, 0), ConflictClosed},
+		{at(9, 0), at(10, 0), ""},
+		// overlap the previous occurrence of the series
+		{at(9, 30), at(10, 30), ConflictUnavailable},
+		// inside the buffer of an occurrence of the series
+		{at(10, 10), at(11, 0), ConflictUnavailable},
+		{at(11, 0), at(11, 40), ""},
+		// inside the buffer of the booking
+		{at(13, 5), at(14, 0), ConflictUnavailable},
+		// the cancelled booking does not conflict
+		{at(14, 30), at(15, 30), ""},
+		{at(16, 30), at(17, 30), ConflictUnavailable},
+		{at(21, 30), at(22, 30), ConflictClosed},
+	}
+	occurrences := make([]Occurrence, 0, len(tests))
+	for _, tt := range tests {
+		occurrences = append(occurrences, Occurrence{StartAt: tt.start, EndAt: tt.end})
+	}
+	if err := s.findConflicts(ctx, v, r, time.UTC, occurrences); err != nil {
+		t.Fatal(err)
+	}
+	for i, tt := range tests {
+		if occurrences[i].Conflict != tt.want {
+			t.Errorf("occurrence %s - %s conflict = %q, want %q",
+				tt.start.Format("01-02 15:04"), tt.end.Format("15:04"), occurrences[i].Conflict, tt.want)
+		}
+	}
+}
+
+// newSeriesTestService return a service with a series of bookings of the customer,
+// the resource policy refund everything until 48 hours before the start and half later
+func newSeriesTestService(now time.Time) (*service, *testRepository) {
+	const price = 1000
+	v := venue.Venue{ID: 1, OwnerID: 20, Timezone: "UTC", OpeningHours: openEveryDay(0, 24*60)}
+	policyID := 7
+	r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true, CancellationPolicyID: &policyID}
+	seriesID, otherSeriesID := 5, 6
+	occurrence := func(id int, startAt time.Time, status Status, seriesID *int) Booking {
+		return Booking{
+			ID:         id,
+			VenueID:    v.ID,
+			ResourceID: r.ID,
+			UserID:     10,
+			StartAt:    startAt,
+			EndAt:      startAt.Add(time.Hour),
+			PartySize:  2,
+			Status:     status,
+			SeriesID:   seriesID,
+			Price:      price,
+		}
+	}
+	repo := newTestRepository(
+		occurrence(1, now.Add(-time.Hour), StatusConfirmed, &seriesID),
+		occurrence(2, now.Add(24*time.Hour), StatusConfirmed, &seriesID),
+		occurrence(3, now.AddDate(0, 0, 7), StatusPending, &seriesID),
+		occurrence(4, now.AddDate(0, 0, 14), StatusCancelled, &seriesID),
+		occurrence(5, now.AddDate(0, 0, 21), StatusConfirmed, &seriesID),
+		occurrence(6, now.AddDate(0, 0, 8), StatusConfirmed, &otherSeriesID),
+		occurrence(7, now.AddDate(0, 0, 9), StatusConfirmed, nil),
+	)
+	s := newTestService(repo, testVenueRepository{venue: v}, r)
+	s.policies = testPolicies{policy: policy.Policy{ID: policyID, VenueID: v.ID, FreeCancellationHours: 48, LateFeePercent: 50}}
+	return s, repo
+}
+
+func TestCancelSeriesBooking(t *testing.T) {
+	ctx := context.Background()
+	customer := server.AuthInfo{UserID: 10, Role: "customer", Permissions: []string{server.PermissionBookingCreate}}
+	owner := server.AuthInfo{UserID: 20, Role: "venue_admin", Permissions: []string{server.PermissionBookingManage}}
+
+	type cancelled struct {
+		id     int
+		fee    int64
+		refund int64
+	}
+	tests := []struct {
+		name  string
+		actor server.AuthInfo
+		id    int
+		scope CancelScope
+		want  []cancelled
+		err   error
+	}{
+		{"one", customer, 3, CancelScopeOne, []cancelled{{3, 0, 1000}}, nil},
+		{"following", customer, 3, CancelScopeFollowing, []cancelled{{3, 0, 1000}, {5, 0, 1000}}, nil},
+		{"all by the customer", customer, 3, CancelScopeAll, []cancelled{{2, 500, 500}, {3, 0, 1000}, {5, 0, 1000}}, nil},
+		{"all by the venue", owner, 5, CancelScopeAll, []cancelled{{2, 0, 1000}, {3, 0, 1000}, {5, 0, 1000}}, nil},
+		{"following of the last one", customer, 5, CancelScopeFollowing, []cancelled{{5, 0, 1000}}, nil},
+		{"other customer", server.AuthInfo{UserID: 11, Role: "customer", Permissions: []string{server.PermissionBookingCreate}}, 3, CancelScopeAll, nil, ErrBookingNotFound},
+		{"not in a series", customer, 7, CancelScopeAll, nil, ErrNotInSeries},
+		{"invalid scope", customer, 3, "next", nil, ErrInvalidCancelScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			now := time.Now().UTC().Truncate(time.Minute)
+			s, repo := newSeriesTestService(now)
+			before := newTestRepository()
+			for id, b := range repo.bookings {
+				before.bookings[id] = b
+			}
+
+			cancellations, err := s.CancelSeriesBooking(ctx, tt.actor, tt.id, tt.scope, " changed plans ")
+			if !errors.Is(err, tt.err) {
+				t.Fatalf("got error %v, want %v", err, tt.err)
+			}
+			var got []cancelled
+			for _, c := range cancellations {
+				got = append(got, cancelled{c.Booking.ID, c.Outcome.Fee, c.Outcome.Refund})
+				if c.Booking.Status != StatusCancelled {
+					t.Errorf("booking %d returned as %s", c.Booking.ID, c.Booking.Status)
+				}
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("cancelled %v, want %v", got, tt.want)
+			}
+
+			// only the returned bookings are cancelled and recorded
+			if len(repo.transitions) != len(tt.want) {
+				t.Errorf("%d transitions recorded, want %d", len(repo.transitions), len(tt.want))
+			}
+			for _, tr := range repo.transitions {
+				if tr.ActorID != tt.actor.UserID || tr.Reason != "changed plans" {
+					t.Errorf("transition of booking %d by %d for %q", tr.BookingID, tr.ActorID, tr.Reason)
+				}
+			}
+			for id, b := range repo.bookings {
+				want := before.bookings[id]
+				for _, c := range tt.want {
+					if c.id == id {
+						want.Status, want.CancellationFee, want.RefundAmount = StatusCancelled, c.fee, c.refund
+					}
+				}
+				if !reflect.DeepEqual(b, want) {
+					t.Errorf("booking %d stored as %+v, want %+v", id, b, want)
+				}
+			}
+		})
+	}
+}
+
+func TestCancelSeriesBookingChangedMeanwhile(t *testing.T) {
+	ctx := context.Background()
+	now := time.Now().UTC().Truncate(time.Minute)
+	s, repo := newSeriesTestService(now)
+	// the venue cancel the last occurrence between the read and the cancellation
+	repo.afterListSeries = func() {
+		repo.mu.Lock()
+		defer repo.mu.Unlock()
+		b := repo.bookings[5]
+		b.Status = StatusCancelled
+		repo.bookings[5] = b
+	}
+
+	_, err := s.CancelSeriesBooking(ctx, server.AuthInfo{UserID: 10, Role: "customer", Permissions: []string{server.PermissionBookingCreate}}, 3, CancelScopeAll, "")
+	if !errors.Is(err, ErrStatusChanged) {
+		t.Fatalf("got error %v, want %v", err, ErrStatusChanged)
+	}
+	for _, id := range []int{2, 3} {
+		if status := repo.bookings[id].Status; status == StatusCancelled {
+			t.Errorf("booking %d cancelled although the series changed", id)
+		}
+	}
+	if len(repo.transitions) != 0 {
+		t.Errorf("%d transitions recorded, want none", len(repo.transitions))
+	}
+}
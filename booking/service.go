@@ -0,0 +1,370 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/booking-man-be/config"
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/schedule"
+	"github.com/booking-man-be/venue"
+	"gorm.io/gorm"
+)
+
+const (
+	maxBookingDuration = 24 * time.Hour
+	// maxActiveHoldsPerVenue limit the slots a user can hold at once at a venue
+	maxActiveHoldsPerVenue = 3
+)
+
+type service struct {
+	repo      Repository
+	venues    venue.Service
+	resources resource.Service
+	schedules schedule.Service
+	policies  policy.Service
+
+	holdExpiresIn time.Duration
+}
+
+type Service interface {
+	CreateBooking(ctx context.Context, actor server.AuthInfo, req CreateBooking) (Booking, error)
+	// HoldSlot keep the slot for the actor until the hold expire, CreateBooking
+	// with the hold ID turn it into a booking
+	HoldSlot(ctx context.Context, actor server.AuthInfo, req HoldSlot) (Hold, error)
+	ReleaseHold(ctx context.Context, actor server.AuthInfo, id int) error
+
+	// the booking lifecycle, every transition is recorded with the actor and
+	// the reason, the customer can only cancel, the others are done by the venue
+	ConfirmBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error)
+	CheckInBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error)
+	CompleteBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error)
+	CancelBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, policy.Outcome, error)
+	PreviewCancellation(ctx context.Context, actor server.AuthInfo, id int) (Booking, policy.Outcome, error)
+	RescheduleBooking(ctx context.Context, actor server.AuthInfo, id int, req RescheduleBooking) (Booking, Reschedule, error)
+
+	// recurring bookings
+	CreateSeries(ctx context.Context, actor server.AuthInfo, req CreateSeries) (Series, []Occurrence, error)
+	GetSeries(ctx context.Context, actor server.AuthInfo, id int) (Series, []Booking, error)
+	CancelSeriesBooking(ctx context.Context, actor server.AuthInfo, id int, scope CancelScope, reason string) ([]Cancellation, error)
+	MarkNoShow(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error)
+	ExpireBooking(ctx context.Context, actor server.AuthInfo, id int, reason string) (Booking, error)
+	ListTransitions(ctx context.Context, actor server.AuthInfo, id int) ([]Transition, error)
+	ExpirePendingBookings(ctx context.Context) (int, error)
+	// GetBooking return the booking to its customer or the manager and staff of the venue
+	GetBooking(ctx context.Context, actor server.AuthInfo, id int) (Booking, error)
+	// ListBusyIntervals return the intervals booked or held on every resource overlapping [from, to)
+	// and the time the first hold expire, zero without hold
+	ListBusyIntervals(ctx context.Context, resourceIDs []int, from, to time.Time) (map[int][]schedule.Interval, time.Time, error)
+	// ResourceVersions return counters which change whenever a booking of the resource change
+	ResourceVersions(ctx context.Context, resourceIDs []int) ([]int64, error)
+}
+
+func NewService(repo Repository, venues venue.Service, resources resource.Service, schedules schedule.Service, policies policy.Service, cfg config.Config) Service {
+	return &service{
+		repo:      repo,
+		venues:    venues,
+		resources: resources,
+		schedules: schedules,
+		policies:  policies,
+
+		holdExpiresIn: time.Duration(cfg.HoldExpiresIn) * time.Minute,
+	}
+}
+
+// CreateBooking reserve the resource for the actor, it fail with ErrSlotUnavailable
+// when another booking, hold or their buffer overlap the interval
+func (s *service) CreateBooking(ctx context.Context, actor server.AuthInfo, req CreateBooking) (Booking, error) {
+	if req.HoldID != 0 {
+		return s.convertHold(ctx, actor, req.HoldID)
+	}
+
+	r, v, err := s.checkSlot(ctx, req.ResourceID, req.StartAt, req.EndAt, req.PartySize)
+	if err != nil {
+		return Booking{}, err
+	}
+	booking := Booking{
+		VenueID:    v.ID,
+		ResourceID: r.ID,
+		UserID:     actor.UserID,
+		StartAt:    req.StartAt.UTC(),
+		EndAt:      req.EndAt.UTC(),
+		PartySize:  req.PartySize,
+		Status:     initialStatus(r),
+		Price:      r.Price(req.EndAt.Sub(req.StartAt)),
+		Currency:   r.Currency,
+	}
+	return s.createBooking(ctx, booking, r.Buffer(), 0)
+}
+
+func (s *service) GetBooking(ctx context.Context, actor server.AuthInfo, id int) (Booking, error) {
+	booking, err := s.repo.GetBooking(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Booking{}, ErrBookingNotFound
+	}
+	if err != nil {
+		return Booking{}, internalError(err)
+	}
+	if booking.UserID == actor.UserID {
+		return booking, nil
+	}
+	// do not reveal bookings of other customers
+	_, err = s.venues.GetStaffedVenue(ctx, actor, booking.VenueID)
+	if errors.Is(err, venue.ErrNotVenueManager) || errors.Is(err, venue.ErrVenueNotFound) {
+		return Booking{}, ErrBookingNotFound
+	}
+	if err != nil {
+		return Booking{}, err
+	}
+	return booking, nil
+}
+
+// HoldSlot check the slot like CreateBooking and hold it for the configured time,
+// a user can hold at most maxActiveHoldsPerVenue slots of a venue at once
+func (s *service) HoldSlot(ctx context.Context, actor server.AuthInfo, req HoldSlot) (Hold, error) {
+	r, v, err := s.checkSlot(ctx, req.ResourceID, req.StartAt, req.EndAt, req.PartySize)
+	if err != nil {
+		return Hold{}, err
+	}
+
+	hold := Hold{
+		VenueID:    v.ID,
+		ResourceID: r.ID,
+		UserID:     actor.UserID,
+		StartAt:    req.StartAt.UTC(),
+		EndAt:      req.EndAt.UTC(),
+		PartySize:  req.PartySize,
+		ExpiresAt:  time.Now().Add(s.holdExpiresIn),
+	}
+	err = s.repo.CreateHold(ctx, &hold, r.Buffer(), maxActiveHoldsPerVenue)
+	if errors.Is(err, errOverlap) {
+		return Hold{}, ErrSlotUnavailable
+	}
+	if errors.Is(err, errTooManyHolds) {
+		return Hold{}, ErrTooManyHolds
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Hold{}, resource.ErrResourceNotFound
+	}
+	if err != nil {
+		return Hold{}, internalError(err)
+	}
+	if err := s.repo.SetHoldKey(ctx, hold.ID, s.holdExpiresIn); err != nil {
+		// a hold missing from redis could never be converted, give the slot back
+		if err := s.repo.ReleaseHold(ctx, hold.ID, time.Now()); err != nil {
+			logger.Errorf("[booking] failed to release hold %d, %v", hold.ID, err)
+		}
+		return Hold{}, internalError(err)
+	}
+	s.resourceChanged(ctx, hold.ResourceID)
+	return hold, nil
+}
+
+// ReleaseHold give the held slot back before the hold expire
+func (s *service) ReleaseHold(ctx context.Context, actor server.AuthInfo, id int) error {
+	hold, err := s.getHold(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	err = s.repo.ReleaseHold(ctx, hold.ID, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrHoldExpired
+	}
+	if err != nil {
+		return internalError(err)
+	}
+	if err := s.repo.DeleteHoldKey(ctx, hold.ID); err != nil {
+		logger.Errorf("[booking] failed to delete hold %d, %v", hold.ID, err)
+	}
+	s.resourceChanged(ctx, hold.ResourceID)
+	return nil
+}
+
+// ListBusyIntervals return the intervals booked or held on every resource,
+// expired holds are left out so the slot is free again without any clean up.
+// The intervals stay valid until the returned time when a hold expire, nothing
+// else is changed on the expiry
+func (s *service) ListBusyIntervals(ctx context.Context, resourceIDs []int, from, to time.Time) (map[int][]schedule.Interval, time.Time, error) {
+	bookings, err := s.repo.ListOccupyingBookings(ctx, resourceIDs, from, to)
+	if err != nil {
+		return nil, time.Time{}, internalError(err)
+	}
+	holds, err := s.repo.ListActiveHolds(ctx, resourceIDs, from, to, time.Now())
+	if err != nil {
+		return nil, time.Time{}, internalError(err)
+	}
+
+	busy := make(map[int][]schedule.Interval, len(resourceIDs))
+	for _, b := range bookings {
+		busy[b.ResourceID] = append(busy[b.ResourceID], schedule.Interval{Start: b.StartAt, End: b.EndAt})
+	}
+	var until time.Time
+	for _, h := range holds {
+		busy[h.ResourceID] = append(busy[h.ResourceID], schedule.Interval{Start: h.StartAt, End: h.EndAt})
+		if until.IsZero() || h.ExpiresAt.Before(until) {
+			until = h.ExpiresAt
+		}
+	}
+	return busy, until, nil
+}
+
+func (s *service) ResourceVersions(ctx context.Context, resourceIDs []int) ([]int64, error) {
+	versions, err := s.repo.GetResourceVersions(ctx, resourceIDs)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return versions, nil
+}
+
+// convertHold book the slot held by the actor
+func (s *service) convertHold(ctx context.Context, actor server.AuthInfo, holdID int) (Booking, error) {
+	hold, err := s.getHold(ctx, actor, holdID)
+	if err != nil {
+		return Booking{}, err
+	}
+	if !hold.IsActive(time.Now()) {
+		return Booking{}, ErrHoldExpired
+	}
+	exists, err := s.repo.HoldKeyExists(ctx, hold.ID)
+	if err != nil {
+		return Booking{}, internalError(err)
+	}
+	if !exists {
+		return Booking{}, ErrHoldExpired
+	}
+
+	r, _, err := s.getBookableResource(ctx, hold.ResourceID)
+	if err != nil {
+		return Booking{}, err
+	}
+	booking := Booking{
+		VenueID:    hold.VenueID,
+		ResourceID: hold.ResourceID,
+		UserID:     hold.UserID,
+		StartAt:    hold.StartAt,
+		EndAt:      hold.EndAt,
+		PartySize:  hold.PartySize,
+		Status:     initialStatus(r),
+		Price:      r.Price(hold.EndAt.Sub(hold.StartAt)),
+		Currency:   r.Currency,
+	}
+	booking, err = s.createBooking(ctx, booking, r.Buffer(), hold.ID)
+	if err != nil {
+		return Booking{}, err
+	}
+	if err := s.repo.DeleteHoldKey(ctx, hold.ID); err != nil {
+		logger.Errorf("[booking] failed to delete hold %d, %v", hold.ID, err)
+	}
+	return booking, nil
+}
+
+func (s *service) createBooking(ctx context.Context, booking Booking, buffer time.Duration, holdID int) (Booking, error) {
+	err := s.repo.CreateBooking(ctx, &booking, buffer, holdID)
+	if errors.Is(err, errOverlap) {
+		return Booking{}, ErrSlotUnavailable
+	}
+	if errors.Is(err, errHoldInactive) {
+		return Booking{}, ErrHoldExpired
+	}
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Booking{}, resource.ErrResourceNotFound
+	}
+	if err != nil {
+		return Booking{}, internalError(err)
+	}
+	s.resourceChanged(ctx, booking.ResourceID)
+	return booking, nil
+}
+
+// getHold return the hold if it belongs to the actor
+func (s *service) getHold(ctx context.Context, actor server.AuthInfo, id int) (Hold, error) {
+	hold, err := s.repo.GetHold(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Hold{}, ErrHoldNotFound
+	}
+	if err != nil {
+		return Hold{}, internalError(err)
+	}
+	if hold.UserID != actor.UserID {
+		return Hold{}, ErrHoldNotFound
+	}
+	return hold, nil
+}
+
+// checkSlot validate the interval and party size and return the bookable resource and its venue
+func (s *service) checkSlot(ctx context.Context, resourceID int, startAt, endAt time.Time, partySize int) (resource.Resource, venue.Venue, error) {
+	if !endAt.After(startAt) || endAt.Sub(startAt) > maxBookingDuration {
+		return resource.Resource{}, venue.Venue{}, ErrInvalidInterval
+	}
+	if startAt.Before(time.Now()) {
+		return resource.Resource{}, venue.Venue{}, ErrBookingInPast
+	}
+
+	r, v, err := s.getBookableResource(ctx, resourceID)
+	if err != nil {
+		return resource.Resource{}, venue.Venue{}, err
+	}
+	if partySize < 1 || partySize > r.Capacity {
+		return resource.Resource{}, venue.Venue{}, ErrInvalidPartySize
+	}
+	if err := s.checkOpen(ctx, v, r, schedule.Interval{Start: startAt, End: endAt}); err != nil {
+		return resource.Resource{}, venue.Venue{}, err
+	}
+	return r, v, nil
+}
+
+// getBookableResource return the active resource and its venue which must not be archived
+func (s *service) getBookableResource(ctx context.Context, resourceID int) (resource.Resource, venue.Venue, error) {
+	r, err := s.resources.GetResource(ctx, resourceID)
+	if err != nil {
+		return resource.Resource{}, venue.Venue{}, err
+	}
+	v, err := s.venues.GetVenue(ctx, r.VenueID)
+	if err != nil {
+		return resource.Resource{}, venue.Venue{}, err
+	}
+	if !r.Active || v.ArchivedAt != nil {
+		return resource.Resource{}, venue.Venue{}, ErrResourceNotBookable
+	}
+	return r, v, nil
+}
+
+// checkOpen make sure the resource is open for the whole interval
+func (s *service) checkOpen(ctx context.Context, v venue.Venue, r resource.Resource, interval schedule.Interval) error {
+	loc, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return internalError(err)
+	}
+	start := interval.Start.In(loc)
+	end := interval.End.Add(-time.Nanosecond).In(loc)
+	startDate := time.Date(start.Year(), start.Month(), start.Day(), 0, 0, 0, 0, loc)
+	endDate := time.Date(end.Year(), end.Month(), end.Day(), 0, 0, 0, 0, loc)
+
+	schedules, err := s.schedules.GetResourceSchedules(ctx, v, []resource.Resource{r}, startDate, endDate)
+	if err != nil {
+		return err
+	}
+	if !schedule.Covers(schedules[r.ID], interval) {
+		return ErrOutsideOpeningHours
+	}
+	return nil
+}
+
+// initialStatus return the status of new booking of the resource
+func initialStatus(r resource.Resource) Status {
+	if r.RequiresConfirmation {
+		return StatusPending
+	}
+	return StatusConfirmed
+}
+
+// resourceChanged invalidate the cached availability of the resource
+func (s *service) resourceChanged(ctx context.Context, resourceID int) {
+	if err := s.repo.IncrResourceVersion(ctx, resourceID); err != nil {
+		logger.Errorf("[booking] failed to bump version of resource %d, %v", resourceID, err)
+	}
+}
@@ -0,0 +1,534 @@
+package booking
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/booking-man-be/config"
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/schedule"
+	"github.com/booking-man-be/venue"
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+// testRepository keep the bookings in memory
+type testRepository struct {
+	Repository
+
+	mu          sync.Mutex
+	bookings    map[int]Booking
+	holds       []Hold
+	holdKeys    map[int]bool
+	transitions []Transition
+	// afterListSeries is called once the series bookings are read
+	afterListSeries func()
+}
+
+func newTestRepository(bookings ...Booking) *testRepository {
+	repo := &testRepository{bookings: map[int]Booking{}, holdKeys: map[int]bool{}}
+	for _, b := range bookings {
+		repo.bookings[b.ID] = b
+	}
+	return repo
+}
+
+func (r *testRepository) GetBooking(ctx context.Context, id int) (Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	booking, ok := r.bookings[id]
+	if !ok {
+		return Booking{}, gorm.ErrRecordNotFound
+	}
+	return booking, nil
+}
+
+// CreateBooking check the overlap like the transaction of the real repository
+func (r *testRepository) CreateBooking(ctx context.Context, booking *Booking, buffer time.Duration, holdID int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	if holdID != 0 && !r.holds[holdID-1].IsActive(now) {
+		return errHoldInactive
+	}
+	if r.overlaps(booking.ResourceID, booking.StartAt.Add(-buffer), booking.EndAt.Add(buffer), now, 0, holdID) {
+		return errOverlap
+	}
+	for id := range r.bookings {
+		if id >= booking.ID {
+			booking.ID = id + 1
+		}
+	}
+	if booking.ID == 0 {
+		booking.ID = 1
+	}
+	r.bookings[booking.ID] = *booking
+	if holdID != 0 {
+		r.holds[holdID-1].BookingID = &booking.ID
+	}
+	return nil
+}
+
+// CreateHold check the limit and the overlap like the transaction of the real repository
+func (r *testRepository) CreateHold(ctx context.Context, hold *Hold, buffer time.Duration, maxActive int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	now := time.Now()
+	active := 0
+	for _, h := range r.holds {
+		if h.VenueID == hold.VenueID && h.UserID == hold.UserID && h.IsActive(now) {
+			active++
+		}
+	}
+	if active >= maxActive {
+		return errTooManyHolds
+	}
+	if r.overlaps(hold.ResourceID, hold.StartAt.Add(-buffer), hold.EndAt.Add(buffer), now, 0, 0) {
+		return errOverlap
+	}
+	hold.ID = len(r.holds) + 1
+	r.holds = append(r.holds, *hold)
+	return nil
+}
+
+// overlaps tell whether an occupying booking other than exceptBookingID or an active
+// hold other than exceptHoldID overlap [from, to)
+func (r *testRepository) overlaps(resourceID int, from, to, now time.Time, exceptBookingID, exceptHoldID int) bool {
+	for _, b := range r.bookings {
+		if b.ID != exceptBookingID && b.ResourceID == resourceID && b.Status.IsOccupying() && b.StartAt.Before(to) && b.EndAt.After(from) {
+			return true
+		}
+	}
+	for _, h := range r.holds {
+		if h.ID != exceptHoldID && h.ResourceID == resourceID && h.IsActive(now) && h.StartAt.Before(to) && h.EndAt.After(from) {
+			return true
+		}
+	}
+	return false
+}
+
+// RescheduleBooking move the booking unless the moved slot is taken or the booking
+// changed since current was read, like the transaction of the real repository
+func (r *testRepository) RescheduleBooking(ctx context.Context, current Booking, moved Booking, buffer time.Duration, transition *Transition) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.overlaps(moved.ResourceID, moved.StartAt.Add(-buffer), moved.EndAt.Add(buffer), time.Now(), current.ID, 0) {
+		return errOverlap
+	}
+	stored, ok := r.bookings[current.ID]
+	if !ok || stored.Status != current.Status || stored.ResourceID != current.ResourceID ||
+		!stored.StartAt.Equal(current.StartAt) || !stored.EndAt.Equal(current.EndAt) {
+		return errStatusChanged
+	}
+	r.bookings[current.ID] = moved
+	r.transitions = append(r.transitions, *transition)
+	return nil
+}
+
+func (r *testRepository) GetHold(ctx context.Context, id int) (Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id < 1 || id > len(r.holds) {
+		return Hold{}, gorm.ErrRecordNotFound
+	}
+	return r.holds[id-1], nil
+}
+
+func (r *testRepository) ReleaseHold(ctx context.Context, id int, now time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if id < 1 || id > len(r.holds) || !r.holds[id-1].IsActive(now) {
+		return gorm.ErrRecordNotFound
+	}
+	r.holds[id-1].ReleasedAt = &now
+	return nil
+}
+
+func (r *testRepository) SetHoldKey(ctx context.Context, id int, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.holdKeys[id] = true
+	return nil
+}
+
+func (r *testRepository) HoldKeyExists(ctx context.Context, id int) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	return r.holdKeys[id], nil
+}
+
+func (r *testRepository) DeleteHoldKey(ctx context.Context, id int) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	delete(r.holdKeys, id)
+	return nil
+}
+
+func (r *testRepository) TransitionBooking(ctx context.Context, transition *Transition, fields map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	booking, ok := r.bookings[transition.BookingID]
+	if !ok || booking.Status != transition.FromStatus {
+		return errStatusChanged
+	}
+	booking.Status = transition.ToStatus
+	if fee, ok := fields["cancellation_fee"]; ok {
+		booking.CancellationFee = fee.(int64)
+	}
+	if refund, ok := fields["refund_amount"]; ok {
+		booking.RefundAmount = refund.(int64)
+	}
+	r.bookings[booking.ID] = booking
+	r.transitions = append(r.transitions, *transition)
+	return nil
+}
+
+func (r *testRepository) ListOccupyingBookings(ctx context.Context, resourceIDs []int, from, to time.Time) ([]Booking, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var bookings []Booking
+	for _, b := range r.bookings {
+		if b.Status.IsOccupying() && b.StartAt.Before(to) && b.EndAt.After(from) {
+			bookings = append(bookings, b)
+		}
+	}
+	return bookings, nil
+}
+
+func (r *testRepository) ListActiveHolds(ctx context.Context, resourceIDs []int, from, to, now time.Time) ([]Hold, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var holds []Hold
+	for _, h := range r.holds {
+		if h.IsActive(now) && h.StartAt.Before(to) && h.EndAt.After(from) {
+			holds = append(holds, h)
+		}
+	}
+	return holds, nil
+}
+
+func (r *testRepository) ListSeriesBookings(ctx context.Context, seriesID int, from time.Time) ([]Booking, error) {
+	r.mu.Lock()
+	var bookings []Booking
+	for _, b := range r.bookings {
+		if b.SeriesID != nil && *b.SeriesID == seriesID && !b.StartAt.Before(from) {
+			bookings = append(bookings, b)
+		}
+	}
+	r.mu.Unlock()
+	sort.Slice(bookings, func(i, j int) bool { return bookings[i].StartAt.Before(bookings[j].StartAt) })
+	if r.afterListSeries != nil {
+		r.afterListSeries()
+	}
+	return bookings, nil
+}
+
+// CancelSeriesBookings cancel every booking or none like the transaction of the real repository
+func (r *testRepository) CancelSeriesBookings(ctx context.Context, seriesID int, cancellations []Cancellation, actorID int, reason string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, c := range cancellations {
+		if b := r.bookings[c.Booking.ID]; b.Status != c.Booking.Status || b.SeriesID == nil || *b.SeriesID != seriesID {
+			return errStatusChanged
+		}
+	}
+	for _, c := range cancellations {
+		b := r.bookings[c.Booking.ID]
+		b.Status = StatusCancelled
+		b.CancellationFee = c.Outcome.Fee
+		b.RefundAmount = c.Outcome.Refund
+		r.bookings[b.ID] = b
+		r.transitions = append(r.transitions, Transition{
+			BookingID:  b.ID,
+			FromStatus: c.Booking.Status,
+			ToStatus:   StatusCancelled,
+			ActorID:    actorID,
+			Reason:     reason,
+		})
+	}
+	return nil
+}
+
+func (r *testRepository) IncrResourceVersion(ctx context.Context, resourceID int) error {
+	return nil
+}
+
+// testVenueRepository keep one venue and its staff for the real venue service
+type testVenueRepository struct {
+	venue.Repository
+	venue venue.Venue
+	staff []int
+}
+
+func (f testVenueRepository) GetVenue(ctx context.Context, id int) (venue.Venue, error) {
+	if id != f.venue.ID {
+		return venue.Venue{}, gorm.ErrRecordNotFound
+	}
+	return f.venue, nil
+}
+
+func (f testVenueRepository) IsStaff(ctx context.Context, venueID, userID int) (bool, error) {
+	for _, id := range f.staff {
+		if venueID == f.venue.ID && userID == id {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+type testResources struct {
+	resource.Service
+	resource resource.Resource
+	others   []resource.Resource
+}
+
+func (f testResources) GetResource(ctx context.Context, id int) (resource.Resource, error) {
+	for _, r := range append([]resource.Resource{f.resource}, f.others...) {
+		if r.ID == id {
+			return r, nil
+		}
+	}
+	return resource.Resource{}, resource.ErrResourceNotFound
+}
+
+type testPolicies struct {
+	policy.Service
+	policy policy.Policy
+}
+
+func (f testPolicies) GetPolicy(ctx context.Context, id int) (policy.Policy, error) {
+	if id != f.policy.ID {
+		return policy.Policy{}, policy.ErrPolicyNotFound
+	}
+	return f.policy, nil
+}
+
+type testExceptions struct {
+	schedule.Repository
+}
+
+func (testExceptions) ListExceptions(ctx context.Context, venueID int, startDate, endDate string) ([]schedule.Exception, error) {
+	return nil, nil
+}
+
+// newTestService wire the booking service on the repository with the real venue and
+// schedule services, the venue and its resources are the only ones known
+func newTestService(repo Repository, venueRepo venue.Repository, r resource.Resource, others ...resource.Resource) *service {
+	venues := venue.NewService(venueRepo)
+	resources := testResources{resource: r, others: others}
+	schedules := schedule.NewService(testExceptions{}, venues, resources)
+	return NewService(repo, venues, resources, schedules, nil, config.Config{HoldExpiresIn: 10}).(*service)
+}
+
+// newTestPool return a redis pool on a miniredis server closed at the end of the test
+func newTestPool(t *testing.T) *redis.Pool {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", mr.Addr())
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+	return pool
+}
+
+// openEveryDay return opening hours from open to close minute on every weekday
+func openEveryDay(open, close int) []venue.OpeningHour {
+	hours := make([]venue.OpeningHour, 0, 7)
+	for d := time.Sunday; d <= time.Saturday; d++ {
+		hours = append(hours, venue.OpeningHour{WeeklyHours: venue.WeeklyHours{Weekday: d, OpenMinute: open, CloseMinute: close}})
+	}
+	return hours
+}
+
+// newHoldTestService return a service on an in memory repository with one venue
+// open all day and the slot of tomorrow 10:00 to 11:00 on its resource
+func newHoldTestService() (*service, *testRepository, resource.Resource, HoldSlot) {
+	v := venue.Venue{ID: 1, OwnerID: 20, Timezone: "UTC", OpeningHours: openEveryDay(0, 24*60)}
+	r := resource.Resource{ID: 1, VenueID: v.ID, Capacity: 4, Active: true}
+	repo := newTestRepository()
+	day := time.Now().UTC().AddDate(0, 0, 1)
+	start := time.Date(day.Year(), day.Month(), day.Day(), 10, 0, 0, 0, time.UTC)
+	slot := HoldSlot{ResourceID: r.ID, StartAt: start, EndAt: start.Add(time.Hour), PartySize: 2}
+	return newTestService(repo, testVenueRepository{venue: v}, r), repo, r, slot
+}
+
+func TestHoldSlotBlocksOtherUsers(t *testing.T) {
+	ctx := context.Background()
+	s, repo, _, slot := newHoldTestService()
+	holder := server.AuthInfo{UserID: 10}
+	other := server.AuthInfo{UserID: 11}
+
+	hold, err := s.HoldSlot(ctx, holder, slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	booking := CreateBooking{ResourceID: slot.ResourceID, StartAt: slot.StartAt, EndAt: slot.EndAt, PartySize: slot.PartySize}
+	if _, err := s.CreateBooking(ctx, other, booking); !errors.Is(err, ErrSlotUnavailable) {
+		t.Fatalf("CreateBooking() by other user error = %v, want %v", err, ErrSlotUnavailable)
+	}
+	if _, err := s.HoldSlot(ctx, other, slot); !errors.Is(err, ErrSlotUnavailable) {
+		t.Fatalf("HoldSlot() by other user error = %v, want %v", err, ErrSlotUnavailable)
+	}
+
+	booked, err := s.CreateBooking(ctx, holder, CreateBooking{HoldID: hold.ID})
+	if err != nil {
+		t.Fatalf("CreateBooking() from the hold error = %v", err)
+	}
+	if booked.UserID != holder.UserID || !booked.StartAt.Equal(slot.StartAt) {
+		t.Errorf("booking = %+v, want the held slot of user %d", booked, holder.UserID)
+	}
+	if stored, _ := repo.GetHold(ctx, hold.ID); stored.BookingID == nil || *stored.BookingID != booked.ID {
+		t.Errorf("hold converted into booking %v, want %d", stored.BookingID, booked.ID)
+	}
+}
+
+func TestExpiredHoldFreeSlot(t *testing.T) {
+	ctx := context.Background()
+	s, repo, r, slot := newHoldTestService()
+	// the hold expired a minute ago and was never cleaned up
+	repo.holds = append(repo.holds, Hold{
+		ID:         1,
+		VenueID:    r.VenueID,
+		ResourceID: r.ID,
+		UserID:     10,
+		StartAt:    slot.StartAt,
+		EndAt:      slot.EndAt,
+		PartySize:  slot.PartySize,
+		ExpiresAt:  time.Now().Add(-time.Minute),
+	})
+	repo.holdKeys[1] = true
+
+	busy, _, err := s.ListBusyIntervals(ctx, []int{r.ID}, slot.StartAt, slot.EndAt)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(busy[r.ID]) != 0 {
+		t.Errorf("busy intervals = %v, want the expired hold left out", busy[r.ID])
+	}
+	booking := CreateBooking{ResourceID: slot.ResourceID, StartAt: slot.StartAt, EndAt: slot.EndAt, PartySize: slot.PartySize}
+	if _, err := s.CreateBooking(ctx, server.AuthInfo{UserID: 11}, booking); err != nil {
+		t.Errorf("CreateBooking() over the expired hold error = %v", err)
+	}
+}
+
+func TestConvertInactiveHold(t *testing.T) {
+	ctx := context.Background()
+	holder := server.AuthInfo{UserID: 10}
+
+	tests := []struct {
+		name     string
+		inactive func(t *testing.T, s *service, repo *testRepository, hold Hold)
+	}{
+		{
+			name: "released",
+			inactive: func(t *testing.T, s *service, repo *testRepository, hold Hold) {
+				if err := s.ReleaseHold(ctx, holder, hold.ID); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+		{
+			name: "expired",
+			inactive: func(t *testing.T, s *service, repo *testRepository, hold Hold) {
+				repo.holds[hold.ID-1].ExpiresAt = time.Now().Add(-time.Second)
+			},
+		},
+		{
+			name: "converted",
+			inactive: func(t *testing.T, s *service, repo *testRepository, hold Hold) {
+				if _, err := s.CreateBooking(ctx, holder, CreateBooking{HoldID: hold.ID}); err != nil {
+					t.Fatal(err)
+				}
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s, repo, _, slot := newHoldTestService()
+			hold, err := s.HoldSlot(ctx, holder, slot)
+			if err != nil {
+				t.Fatal(err)
+			}
+			tt.inactive(t, s, repo, hold)
+
+			if _, err := s.CreateBooking(ctx, holder, CreateBooking{HoldID: hold.ID}); !errors.Is(err, ErrHoldExpired) {
+				t.Errorf("CreateBooking() from %s hold error = %v, want %v", tt.name, err, ErrHoldExpired)
+			}
+			if err := s.ReleaseHold(ctx, holder, hold.ID); !errors.Is(err, ErrHoldExpired) {
+				t.Errorf("ReleaseHold() of %s hold error = %v, want %v", tt.name, err, ErrHoldExpired)
+			}
+		})
+	}
+}
+
+func TestHoldOfOtherUser(t *testing.T) {
+	ctx := context.Background()
+	s, repo, _, slot := newHoldTestService()
+	holder := server.AuthInfo{UserID: 10}
+	other := server.AuthInfo{UserID: 11}
+
+	hold, err := s.HoldSlot(ctx, holder, slot)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := s.ReleaseHold(ctx, other, hold.ID); !errors.Is(err, ErrHoldNotFound) {
+		t.Errorf("ReleaseHold() by other user error = %v, want %v", err, ErrHoldNotFound)
+	}
+	if _, err := s.CreateBooking(ctx, other, CreateBooking{HoldID: hold.ID}); !errors.Is(err, ErrHoldNotFound) {
+		t.Errorf("CreateBooking() from hold of other user error = %v, want %v", err, ErrHoldNotFound)
+	}
+	if stored, _ := repo.GetHold(ctx, hold.ID); !stored.IsActive(time.Now()) || len(repo.bookings) != 0 {
+		t.Error("hold of the user changed by another user")
+	}
+}
+
+func TestHoldSlotLimit(t *testing.T) {
+	ctx := context.Background()
+	s, _, _, slot := newHoldTestService()
+	holder := server.AuthInfo{UserID: 10}
+
+	var holds []Hold
+	for i := 0; i <= maxActiveHoldsPerVenue; i++ {
+		req := slot
+		req.StartAt = slot.StartAt.Add(time.Duration(i) * 2 * time.Hour)
+		req.EndAt = req.StartAt.Add(time.Hour)
+		hold, err := s.HoldSlot(ctx, holder, req)
+		if i == maxActiveHoldsPerVenue {
+			if !errors.Is(err, ErrTooManyHolds) {
+				t.Fatalf("HoldSlot() over the limit error = %v, want %v", err, ErrTooManyHolds)
+			}
+			break
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		holds = append(holds, hold)
+	}
+	// another user is not limited by the holds of the first one
+	other := slot
+	other.StartAt = slot.StartAt.Add(-2 * time.Hour)
+	other.EndAt = other.StartAt.Add(time.Hour)
+	if _, err := s.HoldSlot(ctx, server.AuthInfo{UserID: 11}, other); err != nil {
+		t.Errorf("HoldSlot() by other user error = %v", err)
+	}
+
+	if err := s.ReleaseHold(ctx, holder, holds[0].ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.HoldSlot(ctx, holder, slot); err != nil {
+		t.Errorf("HoldSlot() after release error = %v", err)
+	}
+}
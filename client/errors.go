@@ -0,0 +1,20 @@
+package client
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrClientRequired  = status.Error(codes.Unauthenticated, "clientid header is required")
+	ErrUnknownClient   = status.Error(codes.Unauthenticated, "client is unknown or disabled")
+	ErrGrantNotAllowed = status.Error(codes.PermissionDenied, "grant type is not allowed for this client")
+	ErrInternal        = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[client] %v", err)
+	return ErrInternal
+}
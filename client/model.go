@@ -0,0 +1,63 @@
+package client
+
+import (
+	"strings"
+	"time"
+)
+
+// GrantType is the way a client obtain tokens
+type GrantType string
+
+const (
+	// GrantPassword allow Login with login and password
+	GrantPassword GrantType = "password"
+	// GrantFirebase allow LoginWithFirebase
+	GrantFirebase GrantType = "firebase"
+	// GrantRefreshToken allow RefreshToken
+	GrantRefreshToken GrantType = "refresh_token"
+)
+
+// Client is an application registered to call the API, it is identified by the
+// clientid header. Clients are provisioned by the platform operator in the client table
+type Client struct {
+	ID   string `gorm:"type:varchar(64);primary_key"`
+	Name string `gorm:"type:varchar(100);not null"`
+	// GrantTypes is comma separated list of allowed GrantType
+	GrantTypes string `gorm:"type:varchar(255);not null;default:''"`
+	// Scopes is space separated list of permissions the client may use,
+	// empty means every permission granted to the role of the user
+	Scopes string `gorm:"type:varchar(512);not null;default:''"`
+	// TokenExpiresIn override the default access token lifetime when not zero | minutes unit
+	TokenExpiresIn int `gorm:"not null;default:0"`
+	// RefreshTokenExpiresIn override the default refresh token lifetime when not zero | hours unit
+	RefreshTokenExpiresIn int       `gorm:"not null;default:0"`
+	Enabled               bool      `gorm:"not null;default:true"`
+	CreatedAt             time.Time `gorm:"not null"`
+	UpdatedAt             time.Time `gorm:"not null"`
+}
+
+// AllowGrant check whether the client may obtain tokens with the grant type
+func (c Client) AllowGrant(grant GrantType) bool {
+	for _, g := range strings.Split(c.GrantTypes, ",") {
+		if GrantType(strings.TrimSpace(g)) == grant {
+			return true
+		}
+	}
+	return false
+}
+
+// TokenTTL return access token lifetime of the client
+func (c Client) TokenTTL(fallback time.Duration) time.Duration {
+	if c.TokenExpiresIn > 0 {
+		return time.Duration(c.TokenExpiresIn) * time.Minute
+	}
+	return fallback
+}
+
+// RefreshTokenTTL return refresh token lifetime of the client
+func (c Client) RefreshTokenTTL(fallback time.Duration) time.Duration {
+	if c.RefreshTokenExpiresIn > 0 {
+		return time.Duration(c.RefreshTokenExpiresIn) * time.Hour
+	}
+	return fallback
+}
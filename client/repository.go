@@ -0,0 +1,78 @@
+package client
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+// redis keys
+const (
+	// keyClient cache Client record by ID, disabled or unknown client is not cached
+	keyClient = "client:%s"
+)
+
+// clientCacheTTL is how long a change of the client table may take to be picked up
+const clientCacheTTL = time.Minute
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	GetClient(ctx context.Context, id string) (Client, error)
+
+	GetCachedClient(ctx context.Context, id string) (Client, error)
+	CacheClient(ctx context.Context, client Client) error
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+// GetClient return gorm.ErrRecordNotFound if the client does not exist
+func (r *repository) GetClient(ctx context.Context, id string) (Client, error) {
+	var client Client
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&client).Error
+	return client, err
+}
+
+// GetCachedClient return redis.ErrNil if the client is not cached
+func (r *repository) GetCachedClient(ctx context.Context, id string) (Client, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return Client{}, err
+	}
+	defer conn.Close()
+
+	value, err := redis.Bytes(conn.Do("GET", fmt.Sprintf(keyClient, id)))
+	if err != nil {
+		return Client{}, err
+	}
+	var client Client
+	err = json.Unmarshal(value, &client)
+	return client, err
+}
+
+func (r *repository) CacheClient(ctx context.Context, client Client) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	value, err := json.Marshal(client)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("SET", fmt.Sprintf(keyClient, client.ID), value, "EX", int64(clientCacheTTL.Seconds()))
+	return err
+}
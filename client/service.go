@@ -0,0 +1,76 @@
+package client
+
+import (
+	"context"
+	"errors"
+
+	"github.com/booking-man-be/config"
+	"github.com/booking-man-be/lib/logger"
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+type service struct {
+	repo Repository
+	// defaultClientID is used when the call has no clientid header
+	defaultClientID string
+}
+
+type Service interface {
+	Authorize(ctx context.Context, clientID string, grant GrantType) (Client, error)
+}
+
+func NewService(repo Repository, cfg config.Config) Service {
+	return &service{
+		repo:            repo,
+		defaultClientID: cfg.DefaultClientID,
+	}
+}
+
+// Authorize make sure the client is registered, enabled and allowed to use the
+// grant type. Empty grant only check the client
+func (s *service) Authorize(ctx context.Context, clientID string, grant GrantType) (Client, error) {
+	if clientID == "" {
+		clientID = s.defaultClientID
+	}
+	if clientID == "" {
+		return Client{}, ErrClientRequired
+	}
+
+	client, err := s.getClient(ctx, clientID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Client{}, ErrUnknownClient
+	}
+	if err != nil {
+		return Client{}, internalError(err)
+	}
+	if !client.Enabled {
+		return Client{}, ErrUnknownClient
+	}
+	if grant != "" && !client.AllowGrant(grant) {
+		return Client{}, ErrGrantNotAllowed
+	}
+	return client, nil
+}
+
+// getClient read the client through the redis cache, cache failure fall back to the database
+func (s *service) getClient(ctx context.Context, id string) (Client, error) {
+	client, err := s.repo.GetCachedClient(ctx, id)
+	if err == nil {
+		return client, nil
+	}
+	if err != redis.ErrNil {
+		logger.Errorf("[client] failed to read cached client %s, %v", id, err)
+	}
+
+	client, err = s.repo.GetClient(ctx, id)
+	if err != nil {
+		return Client{}, err
+	}
+	if client.Enabled {
+		if err := s.repo.CacheClient(ctx, client); err != nil {
+			logger.Errorf("[client] failed to cache client %s, %v", id, err)
+		}
+	}
+	return client, nil
+}
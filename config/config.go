@@ -55,6 +55,71 @@ type Config struct {
 	RedisMaxConnLifetime int `envconfig:"REDIS_MAX_CONN_LIFETIME" default:"10"`
 	// Wait to disable/enable redis using only connection from pooling
 	RedisWait bool `envconfig:"REDIS_WAIT" default:"true"`
+
+	// Token Config
+
+	// TokenSecret is secret key to sign access token, at least 32 bytes
+	TokenSecret string `envconfig:"TOKEN_SECRET" required:"true"`
+	// TokenExpiresIn is lifetime of access token | minutes unit
+	TokenExpiresIn int `envconfig:"TOKEN_EXPIRES_IN" default:"15"`
+	// RefreshTokenExpiresIn is lifetime of refresh token | hours unit
+	RefreshTokenExpiresIn int `envconfig:"REFRESH_TOKEN_EXPIRES_IN" default:"720"`
+	// EmailVerificationExpiresIn is lifetime of email verification token | hours unit
+	EmailVerificationExpiresIn int `envconfig:"EMAIL_VERIFICATION_EXPIRES_IN" default:"24"`
+	// PasswordResetExpiresIn is lifetime of password reset code | minutes unit
+	PasswordResetExpiresIn int `envconfig:"PASSWORD_RESET_EXPIRES_IN" default:"15"`
+	// LoginMaxFailures is number of failed login of an account before it is locked
+	LoginMaxFailures int `envconfig:"LOGIN_MAX_FAILURES" default:"5"`
+	// LoginMaxFailuresPerIP is number of failed login from an IP before it is locked
+	LoginMaxFailuresPerIP int `envconfig:"LOGIN_MAX_FAILURES_PER_IP" default:"20"`
+	// LoginFailureWindow is period of counting failed login | minutes unit
+	LoginFailureWindow int `envconfig:"LOGIN_FAILURE_WINDOW" default:"15"`
+	// LoginLockoutBase is first lockout duration, it is doubled on every further failure | seconds unit
+	LoginLockoutBase int `envconfig:"LOGIN_LOCKOUT_BASE" default:"30"`
+	// LoginLockoutMax is the longest lockout duration | minutes unit
+	LoginLockoutMax int `envconfig:"LOGIN_LOCKOUT_MAX" default:"60"`
+	// PreAuthTokenExpiresIn is time to enter TOTP code after password login | minutes unit
+	PreAuthTokenExpiresIn int `envconfig:"PRE_AUTH_TOKEN_EXPIRES_IN" default:"5"`
+	// TOTPIssuer is issuer name shown in authenticator app
+	TOTPIssuer string `envconfig:"TOTP_ISSUER" default:"Booking Man"`
+	// AccountDeletionGracePeriod is time before personal data of deleted account is anonymised | days unit
+	AccountDeletionGracePeriod int `envconfig:"ACCOUNT_DELETION_GRACE_PERIOD" default:"30"`
+
+	// Client Config
+
+	// DefaultClientID is used when a login or token refresh is sent without clientid header,
+	// empty means the header is required
+	DefaultClientID string `envconfig:"DEFAULT_CLIENT_ID" default:""`
+
+	// Booking Config
+
+	// HoldExpiresIn is how long a held slot is kept for the checkout | minutes unit
+	HoldExpiresIn int `envconfig:"HOLD_EXPIRES_IN" default:"10"`
+
+	// Firebase Config
+
+	// FirebaseProjectID is project ID used to verify Firebase ID token
+	FirebaseProjectID string `envconfig:"FIREBASE_PROJECT_ID" default:""`
+	// FirebaseCertsFile load Google public keys from file instead of fetching them,
+	// the file has the same JSON format as the Google public keys URL
+	FirebaseCertsFile string `envconfig:"FIREBASE_CERTS_FILE" default:""`
+
+	// Mailer Config
+
+	// MailerDriver is one of smtp, file or memory
+	MailerDriver string `envconfig:"MAILER_DRIVER" default:"file"`
+	// MailerFrom is sender address of every email
+	MailerFrom string `envconfig:"MAILER_FROM" default:"no-reply@booking-man.local"`
+	// MailerFileDir is directory to write email when using file driver
+	MailerFileDir string `envconfig:"MAILER_FILE_DIR" default:"./mail"`
+	// SMTPHost is host of smtp server
+	SMTPHost string `envconfig:"SMTP_HOST" default:""`
+	// SMTPPort is port of smtp server
+	SMTPPort int `envconfig:"SMTP_PORT" default:"587"`
+	// SMTPUsername is username to authenticate to smtp server
+	SMTPUsername string `envconfig:"SMTP_USERNAME" default:""`
+	// SMTPPassword is password of SMTPUsername
+	SMTPPassword string `envconfig:"SMTP_PASSWORD" default:""`
 }
 
 // Get to get defined configuration
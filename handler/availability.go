@@ -0,0 +1,57 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/booking-man-be/availability"
+	availabilityPb "github.com/booking-man-be/proto/availability"
+	"github.com/golang/protobuf/ptypes"
+)
+
+type availabilityHandler struct {
+	service availability.Service
+}
+
+func NewAvailabilityHandler(service availability.Service) availabilityPb.AvailabilityServer {
+	return &availabilityHandler{
+		service: service,
+	}
+}
+
+func (h *availabilityHandler) SearchAvailability(ctx context.Context, req *availabilityPb.SearchAvailabilityRequest) (*availabilityPb.SearchAvailabilityResponse, error) {
+	resourceIDs := make([]int, 0, len(req.GetResourceIds()))
+	for _, id := range req.GetResourceIds() {
+		resourceIDs = append(resourceIDs, int(id))
+	}
+
+	result, err := h.service.SearchAvailability(ctx, availability.Search{
+		VenueID:     int(req.GetVenueId()),
+		ResourceIDs: resourceIDs,
+		StartDate:   req.GetStartDate(),
+		EndDate:     req.GetEndDate(),
+		Duration:    time.Duration(req.GetDurationMinutes()) * time.Minute,
+		PartySize:   int(req.GetPartySize()),
+		Step:        time.Duration(req.GetStepMinutes()) * time.Minute,
+		Limit:       int(req.GetLimit()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &availabilityPb.SearchAvailabilityResponse{
+		Timezone:  result.Timezone,
+		Slots:     make([]*availabilityPb.Slot, 0, len(result.Slots)),
+		Truncated: result.Truncated,
+	}
+	for _, slot := range result.Slots {
+		start, _ := ptypes.TimestampProto(slot.Start)
+		end, _ := ptypes.TimestampProto(slot.End)
+		resp.Slots = append(resp.Slots, &availabilityPb.Slot{
+			ResourceId: int64(slot.ResourceID),
+			Start:      start,
+			End:        end,
+		})
+	}
+	return resp, nil
+}
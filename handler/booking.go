@@ -0,0 +1,372 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/booking-man-be/booking"
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	bookingPb "github.com/booking-man-be/proto/booking"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+)
+
+type bookingHandler struct {
+	service booking.Service
+}
+
+func NewBookingHandler(service booking.Service) bookingPb.BookingServer {
+	return &bookingHandler{
+		service: service,
+	}
+}
+
+func (h *bookingHandler) CreateBooking(ctx context.Context, req *bookingPb.CreateBookingRequest) (*bookingPb.Booking, error) {
+	create := booking.CreateBooking{
+		HoldID:     int(req.GetHoldId()),
+		ResourceID: int(req.GetResourceId()),
+		PartySize:  int(req.GetPartySize()),
+	}
+	if create.HoldID == 0 {
+		var err error
+		if create.StartAt, create.EndAt, err = bookingInterval(req.GetStart(), req.GetEnd()); err != nil {
+			return nil, err
+		}
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.CreateBooking(ctx, authInfo, create)
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) HoldSlot(ctx context.Context, req *bookingPb.HoldSlotRequest) (*bookingPb.Hold, error) {
+	start, end, err := bookingInterval(req.GetStart(), req.GetEnd())
+	if err != nil {
+		return nil, err
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	hold, err := h.service.HoldSlot(ctx, authInfo, booking.HoldSlot{
+		ResourceID: int(req.GetResourceId()),
+		StartAt:    start,
+		EndAt:      end,
+		PartySize:  int(req.GetPartySize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toHoldPb(hold), nil
+}
+
+func (h *bookingHandler) ReleaseHold(ctx context.Context, req *bookingPb.ReleaseHoldRequest) (*empty.Empty, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if err := h.service.ReleaseHold(ctx, authInfo, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *bookingHandler) GetBooking(ctx context.Context, req *bookingPb.GetBookingRequest) (*bookingPb.Booking, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.GetBooking(ctx, authInfo, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) ConfirmBooking(ctx context.Context, req *bookingPb.BookingTransitionRequest) (*bookingPb.Booking, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.ConfirmBooking(ctx, authInfo, int(req.GetId()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) CheckInBooking(ctx context.Context, req *bookingPb.BookingTransitionRequest) (*bookingPb.Booking, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.CheckInBooking(ctx, authInfo, int(req.GetId()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) CompleteBooking(ctx context.Context, req *bookingPb.BookingTransitionRequest) (*bookingPb.Booking, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.CompleteBooking(ctx, authInfo, int(req.GetId()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) CancelBooking(ctx context.Context, req *bookingPb.BookingTransitionRequest) (*bookingPb.CancelBookingResponse, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, outcome, err := h.service.CancelBooking(ctx, authInfo, int(req.GetId()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return &bookingPb.CancelBookingResponse{
+		Booking:      toBookingPb(b),
+		Cancellation: toCancellationPb(b, outcome),
+	}, nil
+}
+
+func (h *bookingHandler) PreviewCancellation(ctx context.Context, req *bookingPb.PreviewCancellationRequest) (*bookingPb.Cancellation, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, outcome, err := h.service.PreviewCancellation(ctx, authInfo, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toCancellationPb(b, outcome), nil
+}
+
+func (h *bookingHandler) MarkNoShow(ctx context.Context, req *bookingPb.BookingTransitionRequest) (*bookingPb.Booking, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.MarkNoShow(ctx, authInfo, int(req.GetId()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) ExpireBooking(ctx context.Context, req *bookingPb.BookingTransitionRequest) (*bookingPb.Booking, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, err := h.service.ExpireBooking(ctx, authInfo, int(req.GetId()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+	return toBookingPb(b), nil
+}
+
+func (h *bookingHandler) RescheduleBooking(ctx context.Context, req *bookingPb.RescheduleBookingRequest) (*bookingPb.RescheduleBookingResponse, error) {
+	start, end, err := bookingInterval(req.GetStart(), req.GetEnd())
+	if err != nil {
+		return nil, err
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	b, reschedule, err := h.service.RescheduleBooking(ctx, authInfo, int(req.GetId()), booking.RescheduleBooking{
+		ResourceID: int(req.GetResourceId()),
+		StartAt:    start,
+		EndAt:      end,
+		PartySize:  int(req.GetPartySize()),
+		Reason:     req.GetReason(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	var freeUntil *timestamp.Timestamp
+	if reschedule.Fee.FreeUntil != nil {
+		freeUntil, _ = ptypes.TimestampProto(*reschedule.Fee.FreeUntil)
+	}
+	return &bookingPb.RescheduleBookingResponse{
+		Booking:         toBookingPb(b),
+		OldPrice:        reschedule.OldPrice,
+		NewPrice:        reschedule.NewPrice,
+		PriceDifference: reschedule.NewPrice - reschedule.OldPrice,
+		Currency:        b.Currency,
+		Fee: &bookingPb.RescheduleFee{
+			PolicyId:   int64(reschedule.Fee.PolicyID),
+			Rule:       string(reschedule.Fee.Rule),
+			FeePercent: int32(reschedule.Fee.FeePercent),
+			Fee:        reschedule.Fee.Fee,
+			FreeUntil:  freeUntil,
+		},
+	}, nil
+}
+
+func (h *bookingHandler) CreateBookingSeries(ctx context.Context, req *bookingPb.CreateBookingSeriesRequest) (*bookingPb.CreateBookingSeriesResponse, error) {
+	start, end, err := bookingInterval(req.GetStart(), req.GetEnd())
+	if err != nil {
+		return nil, err
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	series, occurrences, err := h.service.CreateSeries(ctx, authInfo, booking.CreateSeries{
+		ResourceID:    int(req.GetResourceId()),
+		StartAt:       start,
+		EndAt:         end,
+		RRule:         req.GetRrule(),
+		PartySize:     int(req.GetPartySize()),
+		AcceptPartial: req.GetAcceptPartial(),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bookingPb.CreateBookingSeriesResponse{
+		Occurrences: make([]*bookingPb.Occurrence, 0, len(occurrences)),
+	}
+	if series.ID != 0 {
+		resp.Series = toBookingSeriesPb(series)
+	}
+	for _, o := range occurrences {
+		start, _ := ptypes.TimestampProto(o.StartAt)
+		end, _ := ptypes.TimestampProto(o.EndAt)
+		resp.Occurrences = append(resp.Occurrences, &bookingPb.Occurrence{
+			Start:     start,
+			End:       end,
+			BookingId: int64(o.BookingID),
+			Conflict:  string(o.Conflict),
+		})
+	}
+	return resp, nil
+}
+
+func (h *bookingHandler) GetBookingSeries(ctx context.Context, req *bookingPb.GetBookingSeriesRequest) (*bookingPb.GetBookingSeriesResponse, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	series, bookings, err := h.service.GetSeries(ctx, authInfo, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bookingPb.GetBookingSeriesResponse{
+		Series:   toBookingSeriesPb(series),
+		Bookings: make([]*bookingPb.Booking, 0, len(bookings)),
+	}
+	for _, b := range bookings {
+		resp.Bookings = append(resp.Bookings, toBookingPb(b))
+	}
+	return resp, nil
+}
+
+func (h *bookingHandler) CancelBookingSeries(ctx context.Context, req *bookingPb.CancelBookingSeriesRequest) (*bookingPb.CancelBookingSeriesResponse, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	cancellations, err := h.service.CancelSeriesBooking(ctx, authInfo, int(req.GetId()), booking.CancelScope(req.GetScope()), req.GetReason())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bookingPb.CancelBookingSeriesResponse{
+		Cancellations: make([]*bookingPb.CancelBookingResponse, 0, len(cancellations)),
+	}
+	for _, c := range cancellations {
+		resp.Cancellations = append(resp.Cancellations, &bookingPb.CancelBookingResponse{
+			Booking:      toBookingPb(c.Booking),
+			Cancellation: toCancellationPb(c.Booking, c.Outcome),
+		})
+	}
+	return resp, nil
+}
+
+func (h *bookingHandler) ListBookingTransitions(ctx context.Context, req *bookingPb.ListBookingTransitionsRequest) (*bookingPb.ListBookingTransitionsResponse, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	transitions, err := h.service.ListTransitions(ctx, authInfo, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &bookingPb.ListBookingTransitionsResponse{
+		Transitions: make([]*bookingPb.BookingTransition, 0, len(transitions)),
+	}
+	for _, t := range transitions {
+		createdAt, _ := ptypes.TimestampProto(t.CreatedAt)
+		resp.Transitions = append(resp.Transitions, &bookingPb.BookingTransition{
+			FromStatus: string(t.FromStatus),
+			ToStatus:   string(t.ToStatus),
+			ActorId:    int64(t.ActorID),
+			Reason:     t.Reason,
+			CreatedAt:  createdAt,
+		})
+	}
+	return resp, nil
+}
+
+func toBookingPb(b booking.Booking) *bookingPb.Booking {
+	start, _ := ptypes.TimestampProto(b.StartAt)
+	end, _ := ptypes.TimestampProto(b.EndAt)
+	createdAt, _ := ptypes.TimestampProto(b.CreatedAt)
+	var seriesID int
+	if b.SeriesID != nil {
+		seriesID = *b.SeriesID
+	}
+	return &bookingPb.Booking{
+		Id:         int64(b.ID),
+		VenueId:    int64(b.VenueID),
+		ResourceId: int64(b.ResourceID),
+		UserId:     int64(b.UserID),
+		Start:      start,
+		End:        end,
+		PartySize:  int32(b.PartySize),
+		Status:     string(b.Status),
+		CreatedAt:  createdAt,
+
+		Price:           b.Price,
+		Currency:        b.Currency,
+		CancellationFee: b.CancellationFee,
+		RefundAmount:    b.RefundAmount,
+		RescheduleFees:  b.RescheduleFees,
+		SeriesId:        int64(seriesID),
+	}
+}
+
+func toBookingSeriesPb(s booking.Series) *bookingPb.BookingSeries {
+	start, _ := ptypes.TimestampProto(s.StartAt)
+	end, _ := ptypes.TimestampProto(s.EndAt)
+	createdAt, _ := ptypes.TimestampProto(s.CreatedAt)
+	return &bookingPb.BookingSeries{
+		Id:         int64(s.ID),
+		VenueId:    int64(s.VenueID),
+		ResourceId: int64(s.ResourceID),
+		UserId:     int64(s.UserID),
+		Rrule:      s.RRule,
+		Start:      start,
+		End:        end,
+		PartySize:  int32(s.PartySize),
+		CreatedAt:  createdAt,
+	}
+}
+
+func toCancellationPb(b booking.Booking, outcome policy.Outcome) *bookingPb.Cancellation {
+	var freeUntil *timestamp.Timestamp
+	if outcome.FreeUntil != nil {
+		freeUntil, _ = ptypes.TimestampProto(*outcome.FreeUntil)
+	}
+	return &bookingPb.Cancellation{
+		PolicyId:   int64(outcome.PolicyID),
+		Rule:       string(outcome.Rule),
+		Price:      b.Price,
+		Currency:   b.Currency,
+		FeePercent: int32(outcome.FeePercent),
+		Fee:        outcome.Fee,
+		Refund:     outcome.Refund,
+		FreeUntil:  freeUntil,
+	}
+}
+
+func toHoldPb(h booking.Hold) *bookingPb.Hold {
+	start, _ := ptypes.TimestampProto(h.StartAt)
+	end, _ := ptypes.TimestampProto(h.EndAt)
+	expiresAt, _ := ptypes.TimestampProto(h.ExpiresAt)
+	return &bookingPb.Hold{
+		Id:         int64(h.ID),
+		VenueId:    int64(h.VenueID),
+		ResourceId: int64(h.ResourceID),
+		Start:      start,
+		End:        end,
+		PartySize:  int32(h.PartySize),
+		ExpiresAt:  expiresAt,
+	}
+}
+
+// bookingInterval convert the requested start and end, both are required
+func bookingInterval(startPb, endPb *timestamp.Timestamp) (time.Time, time.Time, error) {
+	start, err := ptypes.Timestamp(startPb)
+	if err != nil {
+		return time.Time{}, time.Time{}, booking.ErrInvalidInterval
+	}
+	end, err := ptypes.Timestamp(endPb)
+	if err != nil {
+		return time.Time{}, time.Time{}, booking.ErrInvalidInterval
+	}
+	return start, end, nil
+}
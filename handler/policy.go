@@ -0,0 +1,113 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	policyPb "github.com/booking-man-be/proto/policy"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+type policyHandler struct {
+	service policy.Service
+}
+
+func NewPolicyHandler(service policy.Service) policyPb.PolicyServer {
+	return &policyHandler{
+		service: service,
+	}
+}
+
+func (h *policyHandler) CreateCancellationPolicy(ctx context.Context, req *policyPb.CreateCancellationPolicyRequest) (*policyPb.CancellationPolicy, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	p, err := h.service.CreatePolicy(ctx, authInfo, policy.CreatePolicy{
+		VenueID:               int(req.GetVenueId()),
+		Name:                  req.GetName(),
+		NonRefundable:         req.GetNonRefundable(),
+		FreeCancellationHours: int(req.GetFreeCancellationHours()),
+		LateFeePercent:        int(req.GetLateFeePercent()),
+		RescheduleFeePercent:  int(req.GetRescheduleFeePercent()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toCancellationPolicyPb(p), nil
+}
+
+func (h *policyHandler) GetCancellationPolicy(ctx context.Context, req *policyPb.GetCancellationPolicyRequest) (*policyPb.CancellationPolicy, error) {
+	p, err := h.service.GetPolicy(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toCancellationPolicyPb(p), nil
+}
+
+func (h *policyHandler) UpdateCancellationPolicy(ctx context.Context, req *policyPb.UpdateCancellationPolicyRequest) (*policyPb.CancellationPolicy, error) {
+	update := policy.UpdatePolicy{
+		Name: stringValue(req.GetName()),
+	}
+	if req.GetNonRefundable() != nil {
+		nonRefundable := req.GetNonRefundable().GetValue()
+		update.NonRefundable = &nonRefundable
+	}
+	if req.GetFreeCancellationHours() != nil {
+		hours := int(req.GetFreeCancellationHours().GetValue())
+		update.FreeCancellationHours = &hours
+	}
+	if req.GetLateFeePercent() != nil {
+		percent := int(req.GetLateFeePercent().GetValue())
+		update.LateFeePercent = &percent
+	}
+	if req.GetRescheduleFeePercent() != nil {
+		percent := int(req.GetRescheduleFeePercent().GetValue())
+		update.RescheduleFeePercent = &percent
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	p, err := h.service.UpdatePolicy(ctx, authInfo, int(req.GetId()), update)
+	if err != nil {
+		return nil, err
+	}
+	return toCancellationPolicyPb(p), nil
+}
+
+func (h *policyHandler) DeleteCancellationPolicy(ctx context.Context, req *policyPb.DeleteCancellationPolicyRequest) (*empty.Empty, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if err := h.service.DeletePolicy(ctx, authInfo, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *policyHandler) ListCancellationPolicies(ctx context.Context, req *policyPb.ListCancellationPoliciesRequest) (*policyPb.ListCancellationPoliciesResponse, error) {
+	policies, err := h.service.ListPolicies(ctx, int(req.GetVenueId()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &policyPb.ListCancellationPoliciesResponse{
+		Policies: make([]*policyPb.CancellationPolicy, 0, len(policies)),
+	}
+	for _, p := range policies {
+		resp.Policies = append(resp.Policies, toCancellationPolicyPb(p))
+	}
+	return resp, nil
+}
+
+func toCancellationPolicyPb(p policy.Policy) *policyPb.CancellationPolicy {
+	createdAt, _ := ptypes.TimestampProto(p.CreatedAt)
+	updatedAt, _ := ptypes.TimestampProto(p.UpdatedAt)
+	return &policyPb.CancellationPolicy{
+		Id:                    int64(p.ID),
+		VenueId:               int64(p.VenueID),
+		Name:                  p.Name,
+		NonRefundable:         p.NonRefundable,
+		FreeCancellationHours: int32(p.FreeCancellationHours),
+		LateFeePercent:        int32(p.LateFeePercent),
+		CreatedAt:             createdAt,
+		UpdatedAt:             updatedAt,
+		RescheduleFeePercent:  int32(p.RescheduleFeePercent),
+	}
+}
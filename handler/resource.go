@@ -0,0 +1,200 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/booking-man-be/lib/server"
+	resourcePb "github.com/booking-man-be/proto/resource"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+type resourceHandler struct {
+	service resource.Service
+}
+
+func NewResourceHandler(service resource.Service) resourcePb.ResourceServer {
+	return &resourceHandler{
+		service: service,
+	}
+}
+
+func (h *resourceHandler) CreateResource(ctx context.Context, req *resourcePb.CreateResourceRequest) (*resourcePb.Resource, error) {
+	openingHours, err := fromResourceOpeningHoursPb(req.GetOpeningHours())
+	if err != nil {
+		return nil, err
+	}
+	active := true
+	if req.GetActive() != nil {
+		active = req.GetActive().GetValue()
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	r, err := h.service.CreateResource(ctx, authInfo, resource.CreateResource{
+		VenueID:      int(req.GetVenueId()),
+		Name:         req.GetName(),
+		Description:  req.GetDescription(),
+		Type:         req.GetType(),
+		Capacity:     int(req.GetCapacity()),
+		BufferBefore: int(req.GetBufferBeforeMinutes()),
+		BufferAfter:  int(req.GetBufferAfterMinutes()),
+		Active:       active,
+		Tags:         req.GetTags(),
+		OpeningHours: openingHours,
+
+		RequiresConfirmation: req.GetRequiresConfirmation(),
+		HourlyRate:           req.GetHourlyRate(),
+		Currency:             req.GetCurrency(),
+		CancellationPolicyID: int(req.GetCancellationPolicyId()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toResourcePb(r), nil
+}
+
+func (h *resourceHandler) GetResource(ctx context.Context, req *resourcePb.GetResourceRequest) (*resourcePb.Resource, error) {
+	r, err := h.service.GetResource(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toResourcePb(r), nil
+}
+
+func (h *resourceHandler) UpdateResource(ctx context.Context, req *resourcePb.UpdateResourceRequest) (*resourcePb.Resource, error) {
+	update := resource.UpdateResource{
+		Name:        stringValue(req.GetName()),
+		Description: stringValue(req.GetDescription()),
+		Type:        stringValue(req.GetType()),
+	}
+	if req.GetCapacity() != nil {
+		capacity := int(req.GetCapacity().GetValue())
+		update.Capacity = &capacity
+	}
+	if req.GetBufferBeforeMinutes() != nil {
+		bufferBefore := int(req.GetBufferBeforeMinutes().GetValue())
+		update.BufferBefore = &bufferBefore
+	}
+	if req.GetBufferAfterMinutes() != nil {
+		bufferAfter := int(req.GetBufferAfterMinutes().GetValue())
+		update.BufferAfter = &bufferAfter
+	}
+	if req.GetRequiresConfirmation() != nil {
+		requiresConfirmation := req.GetRequiresConfirmation().GetValue()
+		update.RequiresConfirmation = &requiresConfirmation
+	}
+	if req.GetHourlyRate() != nil {
+		hourlyRate := req.GetHourlyRate().GetValue()
+		update.HourlyRate = &hourlyRate
+	}
+	update.Currency = stringValue(req.GetCurrency())
+	if req.GetCancellationPolicyId() != nil {
+		policyID := int(req.GetCancellationPolicyId().GetValue())
+		update.CancellationPolicyID = &policyID
+	}
+	if req.GetActive() != nil {
+		active := req.GetActive().GetValue()
+		update.Active = &active
+	}
+	if req.GetTags() != nil {
+		tags := req.GetTags().GetTags()
+		update.Tags = &tags
+	}
+	if req.GetOpeningHours() != nil {
+		openingHours, err := fromResourceOpeningHoursPb(req.GetOpeningHours().GetHours())
+		if err != nil {
+			return nil, err
+		}
+		update.OpeningHours = &openingHours
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	r, err := h.service.UpdateResource(ctx, authInfo, int(req.GetId()), update)
+	if err != nil {
+		return nil, err
+	}
+	return toResourcePb(r), nil
+}
+
+func (h *resourceHandler) DeleteResource(ctx context.Context, req *resourcePb.DeleteResourceRequest) (*empty.Empty, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if err := h.service.DeleteResource(ctx, authInfo, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *resourceHandler) ListResources(ctx context.Context, req *resourcePb.ListResourcesRequest) (*resourcePb.ListResourcesResponse, error) {
+	resources, total, err := h.service.ListResources(ctx, resource.ListResources{
+		VenueID:         int(req.GetVenueId()),
+		Type:            req.GetType(),
+		Tag:             req.GetTag(),
+		IncludeInactive: req.GetIncludeInactive(),
+		Page:            int(req.GetPage()),
+		PageSize:        int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &resourcePb.ListResourcesResponse{
+		Resources: make([]*resourcePb.Resource, 0, len(resources)),
+		Total:     total,
+	}
+	for _, r := range resources {
+		resp.Resources = append(resp.Resources, toResourcePb(r))
+	}
+	return resp, nil
+}
+
+func fromResourceOpeningHoursPb(hours []*resourcePb.OpeningHour) ([]venue.WeeklyHours, error) {
+	openingHours := make([]venue.WeeklyHours, 0, len(hours))
+	for _, h := range hours {
+		weekly, err := parseWeeklyHours(h.GetWeekday(), h.GetOpensAt(), h.GetClosesAt())
+		if err != nil {
+			return nil, err
+		}
+		openingHours = append(openingHours, weekly)
+	}
+	return openingHours, nil
+}
+
+func toResourcePb(r resource.Resource) *resourcePb.Resource {
+	createdAt, _ := ptypes.TimestampProto(r.CreatedAt)
+	updatedAt, _ := ptypes.TimestampProto(r.UpdatedAt)
+	var policyID int
+	if r.CancellationPolicyID != nil {
+		policyID = *r.CancellationPolicyID
+	}
+	openingHours := make([]*resourcePb.OpeningHour, 0, len(r.OpeningHours))
+	for _, h := range r.OpeningHours {
+		openingHours = append(openingHours, &resourcePb.OpeningHour{
+			Weekday:  int32(h.Weekday),
+			OpensAt:  venue.FormatClock(h.OpenMinute),
+			ClosesAt: venue.FormatClock(h.CloseMinute),
+		})
+	}
+	return &resourcePb.Resource{
+		Id:           int64(r.ID),
+		VenueId:      int64(r.VenueID),
+		Name:         r.Name,
+		Description:  r.Description,
+		Type:         r.Type,
+		Capacity:     int32(r.Capacity),
+		Active:       r.Active,
+		Tags:         r.TagNames(),
+		OpeningHours: openingHours,
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+
+		BufferBeforeMinutes: int32(r.BufferBefore),
+		BufferAfterMinutes:  int32(r.BufferAfter),
+
+		RequiresConfirmation: r.RequiresConfirmation,
+		HourlyRate:           r.HourlyRate,
+		Currency:             r.Currency,
+		CancellationPolicyId: int64(policyID),
+	}
+}
@@ -0,0 +1,115 @@
+package handler
+
+import (
+	"context"
+
+	"github.com/booking-man-be/lib/server"
+	schedulePb "github.com/booking-man-be/proto/schedule"
+	"github.com/booking-man-be/schedule"
+	"github.com/booking-man-be/venue"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+type scheduleHandler struct {
+	service schedule.Service
+}
+
+func NewScheduleHandler(service schedule.Service) schedulePb.ScheduleServer {
+	return &scheduleHandler{
+		service: service,
+	}
+}
+
+func (h *scheduleHandler) CreateScheduleException(ctx context.Context, req *schedulePb.CreateScheduleExceptionRequest) (*schedulePb.ScheduleException, error) {
+	var openMinute, closeMinute int
+	if req.GetOpensAt() != "" || req.GetClosesAt() != "" {
+		var err error
+		if openMinute, err = venue.ParseClock(req.GetOpensAt()); err != nil {
+			return nil, schedule.ErrInvalidHours
+		}
+		if closeMinute, err = venue.ParseClock(req.GetClosesAt()); err != nil {
+			return nil, schedule.ErrInvalidHours
+		}
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	exception, err := h.service.CreateException(ctx, authInfo, schedule.CreateException{
+		VenueID:     int(req.GetVenueId()),
+		ResourceID:  int(req.GetResourceId()),
+		StartDate:   req.GetStartDate(),
+		EndDate:     req.GetEndDate(),
+		Kind:        schedule.ExceptionKind(req.GetKind()),
+		OpenMinute:  openMinute,
+		CloseMinute: closeMinute,
+		Reason:      req.GetReason(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toScheduleExceptionPb(exception), nil
+}
+
+func (h *scheduleHandler) ListScheduleExceptions(ctx context.Context, req *schedulePb.ListScheduleExceptionsRequest) (*schedulePb.ListScheduleExceptionsResponse, error) {
+	exceptions, err := h.service.ListExceptions(ctx, int(req.GetVenueId()), int(req.GetResourceId()), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &schedulePb.ListScheduleExceptionsResponse{
+		Exceptions: make([]*schedulePb.ScheduleException, 0, len(exceptions)),
+	}
+	for _, e := range exceptions {
+		resp.Exceptions = append(resp.Exceptions, toScheduleExceptionPb(e))
+	}
+	return resp, nil
+}
+
+func (h *scheduleHandler) DeleteScheduleException(ctx context.Context, req *schedulePb.DeleteScheduleExceptionRequest) (*empty.Empty, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if err := h.service.DeleteException(ctx, authInfo, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *scheduleHandler) GetSchedule(ctx context.Context, req *schedulePb.GetScheduleRequest) (*schedulePb.GetScheduleResponse, error) {
+	s, err := h.service.GetSchedule(ctx, int(req.GetVenueId()), int(req.GetResourceId()), req.GetStartDate(), req.GetEndDate())
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &schedulePb.GetScheduleResponse{
+		Timezone:  s.Timezone,
+		Intervals: make([]*schedulePb.OpenInterval, 0, len(s.Intervals)),
+	}
+	for _, interval := range s.Intervals {
+		start, _ := ptypes.TimestampProto(interval.Start)
+		end, _ := ptypes.TimestampProto(interval.End)
+		resp.Intervals = append(resp.Intervals, &schedulePb.OpenInterval{
+			Start: start,
+			End:   end,
+		})
+	}
+	return resp, nil
+}
+
+func toScheduleExceptionPb(e schedule.Exception) *schedulePb.ScheduleException {
+	createdAt, _ := ptypes.TimestampProto(e.CreatedAt)
+	var resourceID int64
+	if e.ResourceID != nil {
+		resourceID = int64(*e.ResourceID)
+	}
+	return &schedulePb.ScheduleException{
+		Id:         int64(e.ID),
+		VenueId:    int64(e.VenueID),
+		ResourceId: resourceID,
+		StartDate:  e.StartDate,
+		EndDate:    e.EndDate,
+		Kind:       string(e.Kind),
+		OpensAt:    venue.FormatClock(e.OpenMinute),
+		ClosesAt:   venue.FormatClock(e.CloseMinute),
+		Reason:     e.Reason,
+		CreatedAt:  createdAt,
+	}
+}
@@ -2,12 +2,16 @@ package handler
 
 import (
 	"context"
-	"errors"
+	"strings"
+	"time"
 
-	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/server"
 	userPb "github.com/booking-man-be/proto/user"
 	"github.com/booking-man-be/user"
+	"github.com/golang/protobuf/ptypes"
 	"github.com/golang/protobuf/ptypes/empty"
+	"github.com/golang/protobuf/ptypes/timestamp"
+	"github.com/golang/protobuf/ptypes/wrappers"
 )
 
 type userHandler struct {
@@ -21,6 +25,363 @@ func NewUserHandler(service user.Service) userPb.UserServer {
 }
 
 func (h *userHandler) RegisterUser(ctx context.Context, req *userPb.RegisterUserRequest) (*empty.Empty, error) {
-	logger.Info(req)
-	return nil, errors.New("Not implemented yet")
+	_, err := h.service.RegisterUser(ctx, user.RegisterUser{
+		Email:    req.GetEmail(),
+		Username: req.GetUsername(),
+		Password: req.GetPassword(),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) Login(ctx context.Context, req *userPb.LoginRequest) (*userPb.LoginToken, error) {
+	token, err := h.service.Login(ctx, req.GetLogin(), req.GetPassword(), server.ClientInfoFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toLoginTokenPb(token), nil
+}
+
+func (h *userHandler) LoginWithFirebase(ctx context.Context, req *userPb.LoginWithFirebaseRequest) (*userPb.LoginToken, error) {
+	token, err := h.service.LoginWithFirebase(ctx, req.GetIdToken(), server.ClientInfoFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toLoginTokenPb(token), nil
+}
+
+func (h *userHandler) LoginTOTP(ctx context.Context, req *userPb.LoginTOTPRequest) (*userPb.LoginToken, error) {
+	token, err := h.service.LoginTOTP(ctx, req.GetPreAuthToken(), req.GetCode(), server.ClientInfoFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toLoginTokenPb(token), nil
+}
+
+func (h *userHandler) RefreshToken(ctx context.Context, req *userPb.RefreshTokenRequest) (*userPb.LoginToken, error) {
+	token, err := h.service.RefreshToken(ctx, req.GetRefreshToken(), server.ClientInfoFromContext(ctx))
+	if err != nil {
+		return nil, err
+	}
+	return toLoginTokenPb(token), nil
+}
+
+func (h *userHandler) Logout(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.Logout(ctx, authInfo.SessionID); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) LogoutAll(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.LogoutAll(ctx, authInfo.UserID); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) SetUserRole(ctx context.Context, req *userPb.SetUserRoleRequest) (*empty.Empty, error) {
+	if err := h.service.SetUserRole(ctx, int(req.GetUserId()), user.Role(req.GetRole())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) VerifyEmail(ctx context.Context, req *userPb.VerifyEmailRequest) (*empty.Empty, error) {
+	if err := h.service.VerifyEmail(ctx, req.GetToken()); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) ResendVerificationEmail(ctx context.Context, req *empty.Empty) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.ResendVerificationEmail(ctx, authInfo.UserID); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) RequestPasswordReset(ctx context.Context, req *userPb.RequestPasswordResetRequest) (*empty.Empty, error) {
+	if err := h.service.RequestPasswordReset(ctx, req.GetEmail()); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) ResetPassword(ctx context.Context, req *userPb.ResetPasswordRequest) (*empty.Empty, error) {
+	if err := h.service.ResetPassword(ctx, req.GetEmail(), req.GetCode(), req.GetNewPassword()); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// GetMe accept API keys too, the integration may look up the account the key act for
+// and reading the profile can not change the account
+func (h *userHandler) GetMe(ctx context.Context, req *empty.Empty) (*userPb.UserProfile, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	u, err := h.service.GetUser(ctx, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return toUserProfilePb(u), nil
+}
+
+func (h *userHandler) UpdateProfile(ctx context.Context, req *userPb.UpdateProfileRequest) (*userPb.UserProfile, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	u, err := h.service.UpdateProfile(ctx, authInfo.UserID, user.UpdateProfile{
+		DisplayName: stringValue(req.GetDisplayName()),
+		Phone:       stringValue(req.GetPhone()),
+		AvatarURL:   stringValue(req.GetAvatarUrl()),
+		Locale:      stringValue(req.GetLocale()),
+		Timezone:    stringValue(req.GetTimezone()),
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toUserProfilePb(u), nil
+}
+
+func (h *userHandler) ChangePassword(ctx context.Context, req *userPb.ChangePasswordRequest) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	err = h.service.ChangePassword(ctx, authInfo.UserID, authInfo.SessionID, req.GetCurrentPassword(), req.GetNewPassword())
+	if err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) DeleteAccount(ctx context.Context, req *userPb.DeleteAccountRequest) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.DeleteAccount(ctx, authInfo.UserID, req.GetPassword()); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) EnrollTOTP(ctx context.Context, req *empty.Empty) (*userPb.EnrollTOTPResponse, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	enrollment, err := h.service.EnrollTOTP(ctx, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+	return &userPb.EnrollTOTPResponse{
+		Secret:     enrollment.Secret,
+		OtpauthUri: enrollment.URI,
+	}, nil
+}
+
+func (h *userHandler) ConfirmTOTP(ctx context.Context, req *userPb.ConfirmTOTPRequest) (*userPb.ConfirmTOTPResponse, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	codes, err := h.service.ConfirmTOTP(ctx, authInfo.UserID, req.GetCode())
+	if err != nil {
+		return nil, err
+	}
+	return &userPb.ConfirmTOTPResponse{RecoveryCodes: codes}, nil
+}
+
+func (h *userHandler) DisableTOTP(ctx context.Context, req *userPb.DisableTOTPRequest) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.DisableTOTP(ctx, authInfo.UserID, req.GetCode()); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) ListSessions(ctx context.Context, _ *empty.Empty) (*userPb.ListSessionsResponse, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	sessions, err := h.service.ListSessions(ctx, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userPb.ListSessionsResponse{Sessions: make([]*userPb.Session, 0, len(sessions))}
+	for _, session := range sessions {
+		resp.Sessions = append(resp.Sessions, toSessionPb(session, authInfo.SessionID))
+	}
+	return resp, nil
+}
+
+func (h *userHandler) RevokeSession(ctx context.Context, req *userPb.RevokeSessionRequest) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.RevokeSession(ctx, authInfo.UserID, req.GetSessionId()); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *userHandler) CreateAPIKey(ctx context.Context, req *userPb.CreateAPIKeyRequest) (*userPb.CreateAPIKeyResponse, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var expiresAt *time.Time
+	if req.GetExpiresAt() != nil {
+		t, err := ptypes.Timestamp(req.GetExpiresAt())
+		if err != nil {
+			return nil, user.ErrInvalidAPIKeyExpiry
+		}
+		expiresAt = &t
+	}
+	key, plain, err := h.service.CreateAPIKey(ctx, authInfo.UserID, user.CreateAPIKey{
+		Name:      req.GetName(),
+		Scopes:    req.GetScopes(),
+		ExpiresAt: expiresAt,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &userPb.CreateAPIKeyResponse{
+		ApiKey: toAPIKeyPb(key),
+		Key:    plain,
+	}, nil
+}
+
+func (h *userHandler) ListAPIKeys(ctx context.Context, _ *empty.Empty) (*userPb.ListAPIKeysResponse, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := h.service.ListAPIKeys(ctx, authInfo.UserID)
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &userPb.ListAPIKeysResponse{ApiKeys: make([]*userPb.APIKey, 0, len(keys))}
+	for _, key := range keys {
+		resp.ApiKeys = append(resp.ApiKeys, toAPIKeyPb(key))
+	}
+	return resp, nil
+}
+
+func (h *userHandler) RevokeAPIKey(ctx context.Context, req *userPb.RevokeAPIKeyRequest) (*empty.Empty, error) {
+	authInfo, err := loginAuthInfo(ctx)
+	if err != nil {
+		return nil, err
+	}
+	if err := h.service.RevokeAPIKey(ctx, authInfo.UserID, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+// loginAuthInfo return the caller of account management call, these calls need
+// login token so a leaked API key can not take over the account or mint more keys
+func loginAuthInfo(ctx context.Context) (server.AuthInfo, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if authInfo.APIKeyID != 0 {
+		return server.AuthInfo{}, user.ErrAPIKeyNotAllowed
+	}
+	return authInfo, nil
+}
+
+func toUserProfilePb(u user.User) *userPb.UserProfile {
+	createdAt, _ := ptypes.TimestampProto(u.CreatedAt)
+	return &userPb.UserProfile{
+		Id:            int64(u.ID),
+		Email:         u.Email,
+		Username:      u.Username,
+		Role:          string(u.Role),
+		EmailVerified: u.EmailVerifiedAt != nil,
+		DisplayName:   u.DisplayName,
+		Phone:         u.Phone,
+		AvatarUrl:     u.AvatarURL,
+		Locale:        u.Locale,
+		Timezone:      u.Timezone,
+		CreatedAt:     createdAt,
+	}
+}
+
+func toSessionPb(session user.Session, currentSessionID string) *userPb.Session {
+	lastSeenAt, _ := ptypes.TimestampProto(session.LastSeenAt)
+	createdAt, _ := ptypes.TimestampProto(session.CreatedAt)
+	return &userPb.Session{
+		Id:         session.ID,
+		DeviceId:   session.DeviceID,
+		ClientId:   session.ClientID,
+		UserAgent:  session.UserAgent,
+		Ip:         session.IP,
+		LastSeenAt: lastSeenAt,
+		CreatedAt:  createdAt,
+		Current:    session.ID == currentSessionID,
+	}
+}
+
+func toAPIKeyPb(key user.APIKey) *userPb.APIKey {
+	createdAt, _ := ptypes.TimestampProto(key.CreatedAt)
+	return &userPb.APIKey{
+		Id:         int64(key.ID),
+		Name:       key.Name,
+		Prefix:     key.Prefix,
+		Scopes:     strings.Fields(key.Scopes),
+		ExpiresAt:  timestampPb(key.ExpiresAt),
+		LastUsedAt: timestampPb(key.LastUsedAt),
+		CreatedAt:  createdAt,
+	}
+}
+
+// timestampPb convert optional time into proto timestamp, nil if it is not set
+func timestampPb(t *time.Time) *timestamp.Timestamp {
+	if t == nil {
+		return nil
+	}
+	ts, _ := ptypes.TimestampProto(*t)
+	return ts
+}
+
+// stringValue convert optional proto string into pointer, nil if it is not set
+func stringValue(v *wrappers.StringValue) *string {
+	if v == nil {
+		return nil
+	}
+	return &v.Value
+}
+
+func toLoginTokenPb(token user.LoginToken) *userPb.LoginToken {
+	return &userPb.LoginToken{
+		Token:          token.Token,
+		RefreshToken:   token.RefreshToken,
+		TokenExpiresIn: token.TokenExpiresIn,
+		TokenId:        token.TokenID,
+		MfaRequired:    token.MFARequired,
+		PreAuthToken:   token.PreAuthToken,
+	}
 }
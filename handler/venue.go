@@ -0,0 +1,256 @@
+package handler
+
+import (
+	"context"
+	"time"
+
+	"github.com/booking-man-be/lib/server"
+	venuePb "github.com/booking-man-be/proto/venue"
+	"github.com/booking-man-be/venue"
+	"github.com/golang/protobuf/ptypes"
+	"github.com/golang/protobuf/ptypes/empty"
+)
+
+type venueHandler struct {
+	service venue.Service
+}
+
+func NewVenueHandler(service venue.Service) venuePb.VenueServer {
+	return &venueHandler{
+		service: service,
+	}
+}
+
+func (h *venueHandler) CreateVenue(ctx context.Context, req *venuePb.CreateVenueRequest) (*venuePb.Venue, error) {
+	openingHours, err := fromOpeningHoursPb(req.GetOpeningHours())
+	if err != nil {
+		return nil, err
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	v, err := h.service.CreateVenue(ctx, authInfo, venue.Venue{
+		Name:         req.GetName(),
+		Description:  req.GetDescription(),
+		Address:      fromAddressPb(req.GetAddress()),
+		Location:     fromGeoPointPb(req.GetLocation()),
+		Timezone:     req.GetTimezone(),
+		Contact:      fromContactPb(req.GetContact()),
+		OpeningHours: openingHours,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return toVenuePb(v), nil
+}
+
+func (h *venueHandler) GetVenue(ctx context.Context, req *venuePb.GetVenueRequest) (*venuePb.Venue, error) {
+	v, err := h.service.GetVenue(ctx, int(req.GetId()))
+	if err != nil {
+		return nil, err
+	}
+	return toVenuePb(v), nil
+}
+
+func (h *venueHandler) UpdateVenue(ctx context.Context, req *venuePb.UpdateVenueRequest) (*venuePb.Venue, error) {
+	update := venue.UpdateVenue{
+		Name:        stringValue(req.GetName()),
+		Description: stringValue(req.GetDescription()),
+		Timezone:    stringValue(req.GetTimezone()),
+	}
+	if req.GetAddress() != nil {
+		address := fromAddressPb(req.GetAddress())
+		update.Address = &address
+	}
+	if req.GetLocation() != nil {
+		location := fromGeoPointPb(req.GetLocation())
+		update.Location = &location
+	}
+	if req.GetContact() != nil {
+		contact := fromContactPb(req.GetContact())
+		update.Contact = &contact
+	}
+	if req.GetOpeningHours() != nil {
+		openingHours, err := fromOpeningHoursPb(req.GetOpeningHours().GetHours())
+		if err != nil {
+			return nil, err
+		}
+		update.OpeningHours = &openingHours
+	}
+
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	v, err := h.service.UpdateVenue(ctx, authInfo, int(req.GetId()), update)
+	if err != nil {
+		return nil, err
+	}
+	return toVenuePb(v), nil
+}
+
+func (h *venueHandler) ListVenues(ctx context.Context, req *venuePb.ListVenuesRequest) (*venuePb.ListVenuesResponse, error) {
+	venues, total, err := h.service.ListVenues(ctx, venue.ListVenues{
+		OwnerID:  int(req.GetOwnerId()),
+		City:     req.GetCity(),
+		Query:    req.GetQuery(),
+		Page:     int(req.GetPage()),
+		PageSize: int(req.GetPageSize()),
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &venuePb.ListVenuesResponse{
+		Venues: make([]*venuePb.Venue, 0, len(venues)),
+		Total:  total,
+	}
+	for _, v := range venues {
+		resp.Venues = append(resp.Venues, toVenuePb(v))
+	}
+	return resp, nil
+}
+
+func (h *venueHandler) ArchiveVenue(ctx context.Context, req *venuePb.ArchiveVenueRequest) (*empty.Empty, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if err := h.service.ArchiveVenue(ctx, authInfo, int(req.GetId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *venueHandler) AddVenueStaff(ctx context.Context, req *venuePb.AddVenueStaffRequest) (*venuePb.VenueStaff, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	staff, err := h.service.AddStaff(ctx, authInfo, int(req.GetVenueId()), int(req.GetUserId()))
+	if err != nil {
+		return nil, err
+	}
+	return toVenueStaffPb(staff), nil
+}
+
+func (h *venueHandler) RemoveVenueStaff(ctx context.Context, req *venuePb.RemoveVenueStaffRequest) (*empty.Empty, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	if err := h.service.RemoveStaff(ctx, authInfo, int(req.GetVenueId()), int(req.GetUserId())); err != nil {
+		return nil, err
+	}
+	return &empty.Empty{}, nil
+}
+
+func (h *venueHandler) ListVenueStaff(ctx context.Context, req *venuePb.ListVenueStaffRequest) (*venuePb.ListVenueStaffResponse, error) {
+	authInfo, _ := server.AuthInfoFromContext(ctx)
+	staff, err := h.service.ListStaff(ctx, authInfo, int(req.GetVenueId()))
+	if err != nil {
+		return nil, err
+	}
+
+	resp := &venuePb.ListVenueStaffResponse{
+		Staff: make([]*venuePb.VenueStaff, 0, len(staff)),
+	}
+	for _, s := range staff {
+		resp.Staff = append(resp.Staff, toVenueStaffPb(s))
+	}
+	return resp, nil
+}
+
+func fromAddressPb(a *venuePb.Address) venue.Address {
+	return venue.Address{
+		AddressLine: a.GetAddressLine(),
+		City:        a.GetCity(),
+		Region:      a.GetRegion(),
+		PostalCode:  a.GetPostalCode(),
+		Country:     a.GetCountry(),
+	}
+}
+
+func fromGeoPointPb(p *venuePb.GeoPoint) venue.GeoPoint {
+	return venue.GeoPoint{
+		Latitude:  p.GetLatitude(),
+		Longitude: p.GetLongitude(),
+	}
+}
+
+func fromContactPb(c *venuePb.Contact) venue.Contact {
+	return venue.Contact{
+		Email:   c.GetEmail(),
+		Phone:   c.GetPhone(),
+		Website: c.GetWebsite(),
+	}
+}
+
+func fromOpeningHoursPb(hours []*venuePb.OpeningHour) ([]venue.OpeningHour, error) {
+	openingHours := make([]venue.OpeningHour, 0, len(hours))
+	for _, h := range hours {
+		weekly, err := parseWeeklyHours(h.GetWeekday(), h.GetOpensAt(), h.GetClosesAt())
+		if err != nil {
+			return nil, err
+		}
+		openingHours = append(openingHours, venue.OpeningHour{WeeklyHours: weekly})
+	}
+	return openingHours, nil
+}
+
+func toOpeningHoursPb(hours []venue.OpeningHour) []*venuePb.OpeningHour {
+	openingHours := make([]*venuePb.OpeningHour, 0, len(hours))
+	for _, h := range hours {
+		openingHours = append(openingHours, &venuePb.OpeningHour{
+			Weekday:  int32(h.Weekday),
+			OpensAt:  venue.FormatClock(h.OpenMinute),
+			ClosesAt: venue.FormatClock(h.CloseMinute),
+		})
+	}
+	return openingHours
+}
+
+// parseWeeklyHours convert HH:MM opening interval of proto message
+func parseWeeklyHours(weekday int32, opensAt, closesAt string) (venue.WeeklyHours, error) {
+	opens, err := venue.ParseClock(opensAt)
+	if err != nil {
+		return venue.WeeklyHours{}, err
+	}
+	closes, err := venue.ParseClock(closesAt)
+	if err != nil {
+		return venue.WeeklyHours{}, err
+	}
+	return venue.WeeklyHours{
+		Weekday:     time.Weekday(weekday),
+		OpenMinute:  opens,
+		CloseMinute: closes,
+	}, nil
+}
+
+func toVenuePb(v venue.Venue) *venuePb.Venue {
+	createdAt, _ := ptypes.TimestampProto(v.CreatedAt)
+	updatedAt, _ := ptypes.TimestampProto(v.UpdatedAt)
+	return &venuePb.Venue{
+		Id:          int64(v.ID),
+		OwnerId:     int64(v.OwnerID),
+		Name:        v.Name,
+		Description: v.Description,
+		Address: &venuePb.Address{
+			AddressLine: v.Address.AddressLine,
+			City:        v.Address.City,
+			Region:      v.Address.Region,
+			PostalCode:  v.Address.PostalCode,
+			Country:     v.Address.Country,
+		},
+		Location: &venuePb.GeoPoint{
+			Latitude:  v.Location.Latitude,
+			Longitude: v.Location.Longitude,
+		},
+		Timezone: v.Timezone,
+		Contact: &venuePb.Contact{
+			Email:   v.Contact.Email,
+			Phone:   v.Contact.Phone,
+			Website: v.Contact.Website,
+		},
+		OpeningHours: toOpeningHoursPb(v.OpeningHours),
+		CreatedAt:    createdAt,
+		UpdatedAt:    updatedAt,
+		ArchivedAt:   timestampPb(v.ArchivedAt),
+	}
+}
+
+func toVenueStaffPb(s venue.Staff) *venuePb.VenueStaff {
+	createdAt, _ := ptypes.TimestampProto(s.CreatedAt)
+	return &venuePb.VenueStaff{
+		VenueId:   int64(s.VenueID),
+		UserId:    int64(s.UserID),
+		CreatedAt: createdAt,
+	}
+}
@@ -0,0 +1,177 @@
+package firebase
+
+import (
+	"context"
+	"crypto/rsa"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// defaultKeysMaxAge is used when the response has no Cache-Control max-age
+const defaultKeysMaxAge = time.Hour
+
+type verifier struct {
+	projectID string
+	keys      KeySource
+}
+
+// NewVerifier create Verifier of ID tokens issued for the Firebase project
+func NewVerifier(projectID string, keys KeySource) Verifier {
+	return &verifier{
+		projectID: projectID,
+		keys:      keys,
+	}
+}
+
+// Verify check signature and claims of the ID token as documented in
+// https://firebase.google.com/docs/auth/admin/verify-id-tokens
+func (v *verifier) Verify(ctx context.Context, idToken string) (Claims, error) {
+	if v.projectID == "" {
+		return Claims{}, errors.New("firebase project ID is not configured")
+	}
+	keys, err := v.keys.Keys(ctx)
+	if err != nil {
+		return Claims{}, err
+	}
+
+	var claims Claims
+	_, err = jwt.ParseWithClaims(idToken, &claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodRS256 {
+			return nil, errors.New("unexpected signing method")
+		}
+		kid, _ := t.Header["kid"].(string)
+		key, ok := keys[kid]
+		if !ok {
+			return nil, fmt.Errorf("unknown key ID %q", kid)
+		}
+		return key, nil
+	})
+	if err != nil {
+		return Claims{}, err
+	}
+
+	now := time.Now().Unix()
+	switch {
+	case !claims.VerifyAudience(v.projectID, true):
+		return Claims{}, errors.New("invalid audience")
+	case !claims.VerifyIssuer(issuerPrefix+v.projectID, true):
+		return Claims{}, errors.New("invalid issuer")
+	case claims.Subject == "" || len(claims.Subject) > 128:
+		return Claims{}, errors.New("invalid subject")
+	case claims.AuthTime > now:
+		return Claims{}, errors.New("auth time is in the future")
+	}
+	return claims, nil
+}
+
+type httpKeySource struct {
+	url    string
+	client *http.Client
+
+	mu        sync.Mutex
+	keys      map[string]*rsa.PublicKey
+	expiresAt time.Time
+}
+
+// NewHTTPKeySource fetch the certificates from url and cache them
+// as long as the Cache-Control max-age of the response
+func NewHTTPKeySource(url string, client *http.Client) KeySource {
+	return &httpKeySource{
+		url:    url,
+		client: client,
+	}
+}
+
+func (s *httpKeySource) Keys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	if s.keys != nil && time.Now().Before(s.expiresAt) {
+		return s.keys, nil
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, s.url, nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("fetch firebase public keys, status %d", resp.StatusCode)
+	}
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseCertificates(body)
+	if err != nil {
+		return nil, err
+	}
+
+	s.keys = keys
+	s.expiresAt = time.Now().Add(maxAge(resp.Header.Get("Cache-Control")))
+	return keys, nil
+}
+
+type fileKeySource struct {
+	keys map[string]*rsa.PublicKey
+}
+
+// NewFileKeySource load the certificates once from file with the same
+// format as GooglePublicKeysURL, it is meant for offline tests
+func NewFileKeySource(path string) (KeySource, error) {
+	body, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, err
+	}
+	keys, err := parseCertificates(body)
+	if err != nil {
+		return nil, err
+	}
+	return &fileKeySource{keys: keys}, nil
+}
+
+func (s *fileKeySource) Keys(ctx context.Context) (map[string]*rsa.PublicKey, error) {
+	return s.keys, nil
+}
+
+// parseCertificates parse JSON object of key ID to PEM encoded certificate
+func parseCertificates(body []byte) (map[string]*rsa.PublicKey, error) {
+	var certs map[string]string
+	if err := json.Unmarshal(body, &certs); err != nil {
+		return nil, err
+	}
+	keys := make(map[string]*rsa.PublicKey, len(certs))
+	for kid, cert := range certs {
+		key, err := jwt.ParseRSAPublicKeyFromPEM([]byte(cert))
+		if err != nil {
+			return nil, fmt.Errorf("parse key %s, %v", kid, err)
+		}
+		keys[kid] = key
+	}
+	return keys, nil
+}
+
+func maxAge(cacheControl string) time.Duration {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.HasPrefix(directive, "max-age=") {
+			if seconds, err := strconv.Atoi(strings.TrimPrefix(directive, "max-age=")); err == nil {
+				return time.Duration(seconds) * time.Second
+			}
+		}
+	}
+	return defaultKeysMaxAge
+}
@@ -0,0 +1,35 @@
+package firebase
+
+import (
+	"context"
+	"crypto/rsa"
+
+	"github.com/dgrijalva/jwt-go"
+)
+
+// GooglePublicKeysURL serve the x509 certificates used to sign Firebase ID tokens
+const GooglePublicKeysURL = "https://www.googleapis.com/robot/v1/metadata/x509/securetoken@system.gserviceaccount.com"
+
+// issuerPrefix is followed by the Firebase project ID in the iss claim
+const issuerPrefix = "https://securetoken.google.com/"
+
+// Claims is the payload of verified Firebase ID token,
+// Subject hold the Firebase user ID
+type Claims struct {
+	Email         string `json:"email"`
+	EmailVerified bool   `json:"email_verified"`
+	Name          string `json:"name"`
+	Picture       string `json:"picture"`
+	AuthTime      int64  `json:"auth_time"`
+	jwt.StandardClaims
+}
+
+// KeySource return the public keys by key ID (kid header)
+type KeySource interface {
+	Keys(ctx context.Context) (map[string]*rsa.PublicKey, error)
+}
+
+// Verifier verify Firebase ID token
+type Verifier interface {
+	Verify(ctx context.Context, idToken string) (Claims, error)
+}
@@ -46,6 +46,10 @@ func (f *PrefixTextFormatter) Format(entry *logrus.Entry) ([]byte, error) {
 	return msg, err
 }
 
+func Debugf(format string, args ...interface{}) {
+	logrus.Debugf(format, args...)
+}
+
 func Infof(format string, args ...interface{}) {
 	logrus.Infof(format, args...)
 }
@@ -0,0 +1,21 @@
+package mailer
+
+import "context"
+
+const (
+	DriverSMTP   = "smtp"
+	DriverFile   = "file"
+	DriverMemory = "memory"
+)
+
+// Message is a plain text email
+type Message struct {
+	To      []string
+	Subject string
+	Body    string
+}
+
+// Mailer deliver email message
+type Mailer interface {
+	Send(ctx context.Context, msg Message) error
+}
@@ -0,0 +1,180 @@
+package server
+
+import (
+	"context"
+	"strings"
+	"sync"
+
+	authPb "github.com/booking-man-be/proto/auth"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+	"google.golang.org/protobuf/reflect/protoreflect"
+	"google.golang.org/protobuf/reflect/protoregistry"
+)
+
+var ErrMissingToken = status.Error(codes.Unauthenticated, "missing bearer token or api key")
+
+const (
+	// RolePlatformAdmin is the role of the platform operator, it may act on every venue
+	RolePlatformAdmin = "platform_admin"
+	// PermissionBookingCreate allow to book a resource and manage the own bookings
+	PermissionBookingCreate = "booking:create"
+	// PermissionBookingManage allow to manage bookings of the venues the caller works at
+	PermissionBookingManage = "booking:manage"
+)
+
+type authInfoKey struct{}
+
+// AuthInfo is the identity of authenticated caller
+type AuthInfo struct {
+	UserID int
+	// TokenID is the ID of the access token used in the call
+	TokenID string
+	// SessionID group every token issued from the same login
+	SessionID string
+	// ClientID is the registered client the token was issued to
+	ClientID string
+	// APIKeyID is set when the caller authenticated with an API key instead of a token
+	APIKeyID int
+	Role     string
+	// Permissions is list of permission granted to the caller
+	Permissions []string
+}
+
+// IsPlatformAdmin check whether the caller is the platform operator
+func (a AuthInfo) IsPlatformAdmin() bool {
+	return a.Role == RolePlatformAdmin
+}
+
+// HasPermission check whether the permission is granted to the caller
+func (a AuthInfo) HasPermission(permission string) bool {
+	for _, p := range a.Permissions {
+		if p == permission {
+			return true
+		}
+	}
+	return false
+}
+
+// methodOption is the auth annotation of an rpc declared in the proto file
+type methodOption struct {
+	public      bool
+	permissions []string
+}
+
+// methodOptions cache methodOption by full method name
+var methodOptions sync.Map
+
+// Authenticator validate the bearer token or API key of incoming call,
+// the returned error is sent back to the caller as is
+type Authenticator interface {
+	Authenticate(ctx context.Context, token string) (AuthInfo, error)
+	AuthenticateAPIKey(ctx context.Context, key string) (AuthInfo, error)
+}
+
+// AuthUnaryInterceptor reject call without valid bearer token or apikey header
+// and put the AuthInfo into the context, the bearer token win when both are sent. Method annotated with (auth.public) and
+// publicMethods full method name (e.g. /user.user/Login) can be called anonymously
+func AuthUnaryInterceptor(authenticator Authenticator, publicMethods ...string) grpc.UnaryServerInterceptor {
+	public := make(map[string]bool, len(publicMethods))
+	for _, m := range publicMethods {
+		public[m] = true
+	}
+
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		if public[info.FullMethod] || getMethodOption(info.FullMethod).public {
+			return handler(ctx, req)
+		}
+
+		var authInfo AuthInfo
+		var err error
+		if token := BearerToken(ctx); token != "" {
+			authInfo, err = authenticator.Authenticate(ctx, token)
+		} else if key := lastMetadataValue(ctx, HeaderAPIKey); key != "" {
+			authInfo, err = authenticator.AuthenticateAPIKey(ctx, key)
+		} else {
+			return nil, ErrMissingToken
+		}
+		if err != nil {
+			return nil, err
+		}
+		return handler(ContextWithAuthInfo(ctx, authInfo), req)
+	}
+}
+
+// PermissionUnaryInterceptor enforce the (auth.permission) annotation of the
+// called method, it must be chained after AuthUnaryInterceptor
+func PermissionUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		opt := getMethodOption(info.FullMethod)
+		if len(opt.permissions) == 0 {
+			return handler(ctx, req)
+		}
+
+		authInfo, _ := AuthInfoFromContext(ctx)
+		for _, p := range opt.permissions {
+			if !authInfo.HasPermission(p) {
+				return nil, PermissionDenied(p)
+			}
+		}
+		return handler(ctx, req)
+	}
+}
+
+// PermissionDenied is the error returned when the caller lack the permission
+func PermissionDenied(permission string) error {
+	return status.Errorf(codes.PermissionDenied, "permission %q is required", permission)
+}
+
+// ContextWithAuthInfo return copy of ctx holding the AuthInfo
+func ContextWithAuthInfo(ctx context.Context, info AuthInfo) context.Context {
+	return context.WithValue(ctx, authInfoKey{}, info)
+}
+
+// AuthInfoFromContext return AuthInfo set by AuthUnaryInterceptor
+func AuthInfoFromContext(ctx context.Context) (AuthInfo, bool) {
+	info, ok := ctx.Value(authInfoKey{}).(AuthInfo)
+	return info, ok
+}
+
+// BearerToken read the token from authorization metadata,
+// return empty string if the header is missing or not a bearer token
+func BearerToken(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(HeaderAuthorization)
+	if len(values) == 0 {
+		return ""
+	}
+	parts := strings.SplitN(values[0], " ", 2)
+	if len(parts) != 2 || !strings.EqualFold(parts[0], "Bearer") {
+		return ""
+	}
+	return strings.TrimSpace(parts[1])
+}
+
+// getMethodOption read the auth annotation of the method from the registered proto descriptor
+func getMethodOption(fullMethod string) methodOption {
+	if v, ok := methodOptions.Load(fullMethod); ok {
+		return v.(methodOption)
+	}
+
+	var opt methodOption
+	// /package.service/method to package.service.method
+	name := strings.Replace(strings.TrimPrefix(fullMethod, "/"), "/", ".", 1)
+	desc, err := protoregistry.GlobalFiles.FindDescriptorByName(protoreflect.FullName(name))
+	if err == nil {
+		if md, ok := desc.(protoreflect.MethodDescriptor); ok {
+			opt.public, _ = proto.GetExtension(md.Options(), authPb.E_Public).(bool)
+			opt.permissions, _ = proto.GetExtension(md.Options(), authPb.E_Permission).([]string)
+		}
+	}
+
+	methodOptions.Store(fullMethod, opt)
+	return opt
+}
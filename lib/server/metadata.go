@@ -0,0 +1,134 @@
+package server
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"strings"
+
+	"github.com/golang/protobuf/ptypes"
+	gwruntime "github.com/grpc-ecosystem/grpc-gateway/runtime"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
+)
+
+const (
+	// HeaderRetryAfter tell the client how many seconds to wait before retrying
+	HeaderRetryAfter = "retry-after"
+	// headerForwardedFor is set by the rest gateway with the client address
+	headerForwardedFor = "x-forwarded-for"
+	// headerUserAgent is user agent of gRPC client
+	headerUserAgent = "user-agent"
+	// headerGatewayUserAgent is user agent of HTTP client forwarded by the rest gateway
+	headerGatewayUserAgent = gwruntime.MetadataPrefix + "user-agent"
+)
+
+// forwardedHeaders is custom HTTP headers passed as is to gRPC metadata by the rest gateway
+var forwardedHeaders = map[string]bool{
+	HeaderRequestID: true,
+	HeaderDeviceID:  true,
+	HeaderClientID:  true,
+	HeaderAPIKey:    true,
+}
+
+// ClientInfo describe the application making the call
+type ClientInfo struct {
+	IP        string
+	UserAgent string
+	DeviceID  string
+	ClientID  string
+}
+
+// ClientInfoFromContext read the caller description from incoming metadata
+func ClientInfoFromContext(ctx context.Context) ClientInfo {
+	userAgent := lastMetadataValue(ctx, headerGatewayUserAgent)
+	if userAgent == "" {
+		userAgent = lastMetadataValue(ctx, headerUserAgent)
+	}
+	return ClientInfo{
+		IP:        ClientIP(ctx),
+		UserAgent: userAgent,
+		DeviceID:  lastMetadataValue(ctx, HeaderDeviceID),
+		ClientID:  lastMetadataValue(ctx, HeaderClientID),
+	}
+}
+
+// ClientIP return the address of the caller, call coming through the rest
+// gateway carry the real client address in x-forwarded-for, the last entry
+// is the one appended by the gateway so it can not be spoofed by the client
+func ClientIP(ctx context.Context) string {
+	var ip string
+	if p, ok := peer.FromContext(ctx); ok {
+		if host, _, err := net.SplitHostPort(p.Addr.String()); err == nil {
+			ip = host
+		}
+	}
+
+	if parsed := net.ParseIP(ip); parsed == nil || parsed.IsLoopback() {
+		if forwarded := lastMetadataValue(ctx, headerForwardedFor); forwarded != "" {
+			entries := strings.Split(forwarded, ",")
+			ip = strings.TrimSpace(entries[len(entries)-1])
+		}
+	}
+	return ip
+}
+
+// RetryAfterUnaryInterceptor copy RetryInfo detail of the error status into
+// retry-after header, the rest gateway forward it as HTTP Retry-After header
+func RetryAfterUnaryInterceptor() grpc.UnaryServerInterceptor {
+	return func(ctx context.Context, req interface{}, info *grpc.UnaryServerInfo, handler grpc.UnaryHandler) (interface{}, error) {
+		resp, err := handler(ctx, req)
+		if err == nil {
+			return resp, err
+		}
+
+		st, _ := status.FromError(err)
+		for _, detail := range st.Details() {
+			retryInfo, ok := detail.(*errdetails.RetryInfo)
+			if !ok {
+				continue
+			}
+			delay, derr := ptypes.Duration(retryInfo.GetRetryDelay())
+			if derr != nil {
+				break
+			}
+			// round up so the client never retry too early
+			seconds := int64((delay + 999999999) / 1000000000)
+			grpc.SetHeader(ctx, metadata.Pairs(HeaderRetryAfter, strconv.FormatInt(seconds, 10)))
+			break
+		}
+		return resp, err
+	}
+}
+
+// incomingHeaderMatcher forward our custom headers without prefix
+func incomingHeaderMatcher(key string) (string, bool) {
+	if lower := strings.ToLower(key); forwardedHeaders[lower] {
+		return lower, true
+	}
+	return gwruntime.DefaultHeaderMatcher(key)
+}
+
+// outgoingHeaderMatcher expose retry-after as standard HTTP header,
+// other metadata keep the default Grpc-Metadata- prefix
+func outgoingHeaderMatcher(key string) (string, bool) {
+	if key == HeaderRetryAfter {
+		return "Retry-After", true
+	}
+	return gwruntime.MetadataHeaderPrefix + key, true
+}
+
+func lastMetadataValue(ctx context.Context, key string) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	values := md.Get(key)
+	if len(values) == 0 {
+		return ""
+	}
+	return values[len(values)-1]
+}
@@ -19,6 +19,7 @@ const (
 	HeaderRequestID     = "requestid"
 	HeaderDeviceID      = "deviceid"
 	HeaderClientID      = "clientid"
+	HeaderAPIKey        = "apikey"
 )
 
 const defaultMessageSize = 1024 * 1024 * 20
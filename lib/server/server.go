@@ -51,7 +51,10 @@ type service struct {
 type OptionFunc func(o *Options)
 
 func generateOptions(fs ...OptionFunc) Options {
-	var marshalers []gwruntime.ServeMuxOption
+	marshalers := []gwruntime.ServeMuxOption{
+		gwruntime.WithIncomingHeaderMatcher(incomingHeaderMatcher),
+		gwruntime.WithOutgoingHeaderMatcher(outgoingHeaderMatcher),
+	}
 
 	opts := Options{
 		gRPCPort: DefaultGRPCPort,
@@ -256,7 +259,7 @@ func allowCORS(h http.Handler) http.Handler {
 }
 
 func preflightHandler(w http.ResponseWriter, r *http.Request) {
-	headers := []string{HeaderContentType, HeaderAccept, HeaderAuthorization}
+	headers := []string{HeaderContentType, HeaderAccept, HeaderAuthorization, HeaderRequestID, HeaderDeviceID, HeaderClientID, HeaderAPIKey}
 	w.Header().Set("Access-Control-Allow-Headers", strings.Join(headers, ","))
 	methods := []string{"GET", "HEAD", "POST", "PUT", "DELETE"}
 	w.Header().Set("Access-Control-Allow-Methods", strings.Join(methods, ","))
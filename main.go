@@ -1,15 +1,32 @@
 package main
 
 import (
+	"context"
 	"fmt"
+	"net/http"
 	"time"
 
+	"github.com/booking-man-be/availability"
+	"github.com/booking-man-be/booking"
+	"github.com/booking-man-be/client"
 	"github.com/booking-man-be/config"
 	"github.com/booking-man-be/handler"
+	"github.com/booking-man-be/lib/firebase"
 	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/mailer"
 	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	availabilityPb "github.com/booking-man-be/proto/availability"
+	bookingPb "github.com/booking-man-be/proto/booking"
+	policyPb "github.com/booking-man-be/proto/policy"
+	resourcePb "github.com/booking-man-be/proto/resource"
+	schedulePb "github.com/booking-man-be/proto/schedule"
 	userPb "github.com/booking-man-be/proto/user"
+	venuePb "github.com/booking-man-be/proto/venue"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/schedule"
 	"github.com/booking-man-be/user"
+	"github.com/booking-man-be/venue"
 	"github.com/gomodule/redigo/redis"
 	"gorm.io/driver/mysql"
 	"gorm.io/gorm"
@@ -21,13 +38,44 @@ func main() {
 	cfg := config.Get()
 	// init db
 	db := initDB(cfg)
+	migrateDB(db)
 	redis := connectRedis(cfg)
+	mail := initMailer(cfg)
+	firebaseVerifier := initFirebase(cfg)
 
 	// init repo
+	clientRepository := client.NewRepository(db, redis)
 	userRepository := user.NewRepository(db, redis)
+	venueRepository := venue.NewRepository(db, redis)
+	policyRepository := policy.NewRepository(db, redis)
+	resourceRepository := resource.NewRepository(db, redis)
+	scheduleRepository := schedule.NewRepository(db, redis)
+	bookingRepository := booking.NewRepository(db, redis)
+	availabilityRepository := availability.NewRepository(db, redis)
 
 	// init service
-	userService := user.NewService(userRepository)
+	clientService := client.NewService(clientRepository, cfg)
+	userService, err := user.NewService(userRepository, mail, firebaseVerifier, clientService, cfg)
+	if err != nil {
+		logger.Panicf("[ERR] Invalid token config, %s", err.Error())
+	}
+	venueService := venue.NewService(venueRepository)
+	policyService := policy.NewService(policyRepository, venueService, resourceRepository)
+	resourceService := resource.NewService(resourceRepository, venueService, policyService)
+	scheduleService := schedule.NewService(scheduleRepository, venueService, resourceService)
+	bookingService := booking.NewService(bookingRepository, venueService, resourceService, scheduleService, policyService, cfg)
+	availabilityService := availability.NewService(availabilityRepository, venueService, resourceService, scheduleService, bookingService)
+
+	// erase personal data of deleted accounts once the grace period is over
+	go runPeriodically(time.Hour, func(ctx context.Context) error {
+		_, err := userService.AnonymizeDeletedUsers(ctx)
+		return err
+	})
+	// expire pending bookings which were not confirmed before they started
+	go runPeriodically(time.Minute, func(ctx context.Context) error {
+		_, err := bookingService.ExpirePendingBookings(ctx)
+		return err
+	})
 
 	// TODO change port to config
 	svc := server.NewService(
@@ -35,14 +83,39 @@ func main() {
 		server.RESTPort("80"),
 	)
 
+	// every rpc require bearer token except the one annotated with (auth.public)
+	svc.UseServerUnaryInterceptor(
+		server.RetryAfterUnaryInterceptor(),
+		server.AuthUnaryInterceptor(userService),
+		server.PermissionUnaryInterceptor(),
+	)
+
 	svc.Init()
 
 	// init handler
 	userHandler := handler.NewUserHandler(userService)
+	venueHandler := handler.NewVenueHandler(venueService)
+	policyHandler := handler.NewPolicyHandler(policyService)
+	resourceHandler := handler.NewResourceHandler(resourceService)
+	scheduleHandler := handler.NewScheduleHandler(scheduleService)
+	availabilityHandler := handler.NewAvailabilityHandler(availabilityService)
+	bookingHandler := handler.NewBookingHandler(bookingService)
 
 	// register handler to grpc and rest
 	userPb.RegisterUserServer(svc.Server(), userHandler)
 	svc.RegisterRESTHandler(userPb.RegisterUserHandler)
+	venuePb.RegisterVenueServer(svc.Server(), venueHandler)
+	svc.RegisterRESTHandler(venuePb.RegisterVenueHandler)
+	policyPb.RegisterPolicyServer(svc.Server(), policyHandler)
+	svc.RegisterRESTHandler(policyPb.RegisterPolicyHandler)
+	resourcePb.RegisterResourceServer(svc.Server(), resourceHandler)
+	svc.RegisterRESTHandler(resourcePb.RegisterResourceHandler)
+	schedulePb.RegisterScheduleServer(svc.Server(), scheduleHandler)
+	svc.RegisterRESTHandler(schedulePb.RegisterScheduleHandler)
+	availabilityPb.RegisterAvailabilityServer(svc.Server(), availabilityHandler)
+	svc.RegisterRESTHandler(availabilityPb.RegisterAvailabilityHandler)
+	bookingPb.RegisterBookingServer(svc.Server(), bookingHandler)
+	svc.RegisterRESTHandler(bookingPb.RegisterBookingHandler)
 
 	if err := <-svc.RunServers(); err != nil {
 		logger.Fatal(err)
@@ -50,6 +123,18 @@ func main() {
 
 }
 
+// runPeriodically call job every interval until the process exit
+func runPeriodically(interval time.Duration, job func(ctx context.Context) error) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := job(context.Background()); err != nil {
+			logger.Errorf("[ERR] Periodic job failed, %s", err.Error())
+		}
+		<-ticker.C
+	}
+}
+
 func initDB(cfg config.Config) *gorm.DB {
 	dsn := fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=%s&parseTime=%+v&loc=%s", cfg.MysqlUsername, cfg.MysqlPassword, cfg.MysqlHost, cfg.MysqlPort, cfg.MysqlDBName, cfg.MysqlCharset, cfg.MysqlParseTime, cfg.MysqlLoc)
 	// open mysql connecion
@@ -72,6 +157,58 @@ func initDB(cfg config.Config) *gorm.DB {
 
 }
 
+func initMailer(cfg config.Config) mailer.Mailer {
+	switch cfg.MailerDriver {
+	case mailer.DriverSMTP:
+		return mailer.NewSMTPMailer(cfg.SMTPHost, cfg.SMTPPort, cfg.SMTPUsername, cfg.SMTPPassword, cfg.MailerFrom)
+	case mailer.DriverFile:
+		return mailer.NewFileMailer(cfg.MailerFileDir, cfg.MailerFrom)
+	case mailer.DriverMemory:
+		return mailer.NewMemoryMailer()
+	default:
+		logger.Panicf("[ERR] Unknown mailer driver %s", cfg.MailerDriver)
+		return nil
+	}
+}
+
+func initFirebase(cfg config.Config) firebase.Verifier {
+	if cfg.FirebaseCertsFile == "" {
+		keys := firebase.NewHTTPKeySource(firebase.GooglePublicKeysURL, &http.Client{Timeout: 10 * time.Second})
+		return firebase.NewVerifier(cfg.FirebaseProjectID, keys)
+	}
+
+	keys, err := firebase.NewFileKeySource(cfg.FirebaseCertsFile)
+	if err != nil {
+		logger.Panicf("[ERR] Failed to load firebase certificates, %s", err.Error())
+	}
+	return firebase.NewVerifier(cfg.FirebaseProjectID, keys)
+}
+
+// migrateDB keep the database schema in sync with the models
+func migrateDB(db *gorm.DB) {
+	err := db.AutoMigrate(
+		&client.Client{},
+		&user.User{},
+		&user.Session{},
+		&user.APIKey{},
+		&venue.Venue{},
+		&venue.OpeningHour{},
+		&venue.Staff{},
+		&policy.Policy{},
+		&resource.Resource{},
+		&resource.Tag{},
+		&resource.OpeningHour{},
+		&schedule.Exception{},
+		&booking.Booking{},
+		&booking.Transition{},
+		&booking.Hold{},
+		&booking.Series{},
+	)
+	if err != nil {
+		logger.Panicf("[ERR] Database migration failed, %s", err.Error())
+	}
+}
+
 func connectRedis(cfg config.Config) *redis.Pool {
 	// construct URL from host and port
 	URL := fmt.Sprintf("%s:%d", cfg.RedisHost, cfg.RedisPort)
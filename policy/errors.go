@@ -0,0 +1,22 @@
+package policy
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidName                  = status.Error(codes.InvalidArgument, "policy name must be 1-100 characters")
+	ErrInvalidFreeCancellationHours = status.Error(codes.InvalidArgument, "free cancellation hours must be between 0 and 720")
+	ErrInvalidLateFeePercent        = status.Error(codes.InvalidArgument, "late fee percent must be between 0 and 100")
+	ErrInvalidRescheduleFeePercent  = status.Error(codes.InvalidArgument, "reschedule fee percent must be between 0 and 100")
+	ErrPolicyNotFound               = status.Error(codes.NotFound, "cancellation policy not found")
+	ErrInternal                     = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[policy] %v", err)
+	return ErrInternal
+}
@@ -0,0 +1,71 @@
+package policy
+
+import (
+	"time"
+)
+
+// Evaluate compute the outcome of a customer cancellation at now of a booking
+// starting at startAt, p is nil when the resource has no policy
+func Evaluate(p *Policy, price int64, startAt, now time.Time) Outcome {
+	if p == nil {
+		return Outcome{Rule: RuleNoPolicy, Refund: price}
+	}
+	if p.NonRefundable {
+		return outcome(p.ID, RuleNonRefundable, 100, price, nil)
+	}
+
+	freeUntil := startAt.Add(-time.Duration(p.FreeCancellationHours) * time.Hour)
+	if now.Before(freeUntil) {
+		return outcome(p.ID, RuleFree, 0, price, &freeUntil)
+	}
+	return outcome(p.ID, RuleLateFee, p.LateFeePercent, price, &freeUntil)
+}
+
+// EvaluateReschedule compute the fee of moving at now a booking starting at startAt,
+// it is free before the free cancellation deadline, p is nil when the resource has no policy
+func EvaluateReschedule(p *Policy, price int64, startAt, now time.Time) Outcome {
+	if p == nil {
+		return Outcome{Rule: RuleNoPolicy}
+	}
+	if p.NonRefundable {
+		return rescheduleOutcome(p.ID, RuleNonRefundable, p.RescheduleFeePercent, price, nil)
+	}
+
+	freeUntil := startAt.Add(-time.Duration(p.FreeCancellationHours) * time.Hour)
+	if now.Before(freeUntil) {
+		return rescheduleOutcome(p.ID, RuleFree, 0, price, &freeUntil)
+	}
+	return rescheduleOutcome(p.ID, RuleLateFee, p.RescheduleFeePercent, price, &freeUntil)
+}
+
+// FullRefund is the outcome of a cancellation by the venue
+func FullRefund(price int64) Outcome {
+	return Outcome{Rule: RuleCancelledByVenue, Refund: price}
+}
+
+func outcome(policyID int, rule Rule, feePercent int, price int64, freeUntil *time.Time) Outcome {
+	fee := percentOf(price, feePercent)
+	return Outcome{
+		PolicyID:   policyID,
+		Rule:       rule,
+		FeePercent: feePercent,
+		Fee:        fee,
+		Refund:     price - fee,
+		FreeUntil:  freeUntil,
+	}
+}
+
+func rescheduleOutcome(policyID int, rule Rule, feePercent int, price int64, freeUntil *time.Time) Outcome {
+	return Outcome{
+		PolicyID:   policyID,
+		Rule:       rule,
+		FeePercent: feePercent,
+		Fee:        percentOf(price, feePercent),
+		FreeUntil:  freeUntil,
+	}
+}
+
+// percentOf round half up to the minor currency unit
+func percentOf(price int64, percent int) int64 {
+	return (price*int64(percent) + 50) / 100
+}
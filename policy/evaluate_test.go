@@ -0,0 +1,159 @@
+package policy
+
+import (
+	"reflect"
+	"testing"
+	"time"
+)
+
+func TestEvaluate(t *testing.T) {
+	startAt := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	// the free cancellation deadline of flexible is 24 hours before the start
+	deadline := startAt.Add(-24 * time.Hour)
+	flexible := func(lateFeePercent int) *Policy {
+		return &Policy{ID: 7, FreeCancellationHours: 24, LateFeePercent: lateFeePercent}
+	}
+
+	tests := []struct {
+		name  string
+		p     *Policy
+		price int64
+		now   time.Time
+		want  Outcome
+	}{
+		{
+			name:  "no policy",
+			price: 10000,
+			now:   startAt.Add(-time.Hour),
+			want:  Outcome{Rule: RuleNoPolicy, Refund: 10000},
+		},
+		{
+			name:  "non refundable",
+			p:     &Policy{ID: 7, NonRefundable: true, FreeCancellationHours: 24, LateFeePercent: 50},
+			price: 10000,
+			now:   startAt.Add(-48 * time.Hour),
+			want:  Outcome{PolicyID: 7, Rule: RuleNonRefundable, FeePercent: 100, Fee: 10000},
+		},
+		{
+			name:  "before the deadline",
+			p:     flexible(50),
+			price: 10000,
+			now:   deadline.Add(-time.Nanosecond),
+			want:  Outcome{PolicyID: 7, Rule: RuleFree, Refund: 10000, FreeUntil: &deadline},
+		},
+		{
+			name:  "exactly at the deadline",
+			p:     flexible(50),
+			price: 10000,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 50, Fee: 5000, Refund: 5000, FreeUntil: &deadline},
+		},
+		{
+			name:  "after the start",
+			p:     flexible(50),
+			price: 10000,
+			now:   startAt.Add(time.Hour),
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 50, Fee: 5000, Refund: 5000, FreeUntil: &deadline},
+		},
+		{
+			name:  "no free cancellation",
+			p:     &Policy{ID: 7, LateFeePercent: 20},
+			price: 10000,
+			now:   startAt,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 20, Fee: 2000, Refund: 8000, FreeUntil: &startAt},
+		},
+		{
+			name:  "zero price",
+			p:     flexible(50),
+			price: 0,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 50, FreeUntil: &deadline},
+		},
+		{
+			name:  "zero price non refundable",
+			p:     &Policy{ID: 7, NonRefundable: true},
+			price: 0,
+			now:   startAt,
+			want:  Outcome{PolicyID: 7, Rule: RuleNonRefundable, FeePercent: 100},
+		},
+		{
+			name:  "100% late fee",
+			p:     flexible(100),
+			price: 10000,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 100, Fee: 10000, FreeUntil: &deadline},
+		},
+		{
+			name:  "0% late fee",
+			p:     flexible(0),
+			price: 10000,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, Refund: 10000, FreeUntil: &deadline},
+		},
+		{
+			name:  "fee rounded half up",
+			p:     flexible(50),
+			price: 1,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 50, Fee: 1, FreeUntil: &deadline},
+		},
+		{
+			name:  "fee rounded up",
+			p:     flexible(15),
+			price: 999,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 15, Fee: 150, Refund: 849, FreeUntil: &deadline},
+		},
+		{
+			name:  "fee rounded down",
+			p:     flexible(10),
+			price: 1004,
+			now:   deadline,
+			want:  Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 10, Fee: 100, Refund: 904, FreeUntil: &deadline},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := Evaluate(tt.p, tt.price, startAt, tt.now)
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Evaluate() = %+v, want %+v", got, tt.want)
+			}
+			if got.Fee+got.Refund != tt.price {
+				t.Errorf("fee %d and refund %d do not add up to the price %d", got.Fee, got.Refund, tt.price)
+			}
+		})
+	}
+}
+
+func TestEvaluateReschedule(t *testing.T) {
+	startAt := time.Date(2026, 6, 1, 18, 0, 0, 0, time.UTC)
+	deadline := startAt.Add(-24 * time.Hour)
+	p := &Policy{ID: 7, FreeCancellationHours: 24, LateFeePercent: 50, RescheduleFeePercent: 15}
+
+	tests := []struct {
+		name  string
+		p     *Policy
+		price int64
+		now   time.Time
+		want  Outcome
+	}{
+		{"no policy", nil, 10000, deadline, Outcome{Rule: RuleNoPolicy}},
+		{"before the deadline", p, 10000, deadline.Add(-time.Nanosecond), Outcome{PolicyID: 7, Rule: RuleFree, FreeUntil: &deadline}},
+		{"exactly at the deadline", p, 999, deadline, Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 15, Fee: 150, FreeUntil: &deadline}},
+		{"zero price", p, 0, deadline, Outcome{PolicyID: 7, Rule: RuleLateFee, FeePercent: 15, FreeUntil: &deadline}},
+		{
+			name:  "non refundable charge the reschedule fee",
+			p:     &Policy{ID: 7, NonRefundable: true, RescheduleFeePercent: 100},
+			price: 10000,
+			now:   deadline.Add(-48 * time.Hour),
+			want:  Outcome{PolicyID: 7, Rule: RuleNonRefundable, FeePercent: 100, Fee: 10000},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := EvaluateReschedule(tt.p, tt.price, startAt, tt.now); !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("EvaluateReschedule() = %+v, want %+v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,77 @@
+package policy
+
+import (
+	"time"
+)
+
+// Policy decide how much of the price is kept when a customer cancel a booking,
+// it is defined by a venue and attached to its resources
+type Policy struct {
+	ID      int    `gorm:"primary_key"`
+	VenueID int    `gorm:"not null;index"`
+	Name    string `gorm:"type:varchar(100);not null"`
+	// NonRefundable keep the whole price whenever the booking is cancelled
+	NonRefundable bool `gorm:"not null"`
+	// FreeCancellationHours is how long before the start the booking can still
+	// be cancelled for free | hours unit
+	FreeCancellationHours int `gorm:"not null"`
+	// LateFeePercent is the part of the price kept when the booking is cancelled later
+	LateFeePercent int `gorm:"not null"`
+	// RescheduleFeePercent is the part of the price charged when the booking is
+	// moved after the free cancellation deadline
+	RescheduleFeePercent int       `gorm:"not null;default:0"`
+	CreatedAt            time.Time `gorm:"not null"`
+	UpdatedAt            time.Time `gorm:"not null"`
+}
+
+func (Policy) TableName() string {
+	return "cancellation_policy"
+}
+
+// Rule is the part of the policy which applied to a cancellation
+type Rule string
+
+const (
+	// RuleNoPolicy is used when the resource has no policy, everything is refunded
+	RuleNoPolicy Rule = "no_policy"
+	// RuleFree is cancellation before the free cancellation deadline
+	RuleFree Rule = "free"
+	// RuleLateFee is cancellation after the free cancellation deadline
+	RuleLateFee       Rule = "late_fee"
+	RuleNonRefundable Rule = "non_refundable"
+	// RuleCancelledByVenue is used when the venue cancel, everything is refunded
+	RuleCancelledByVenue Rule = "cancelled_by_venue"
+	// RuleRescheduledByVenue is used when the venue move the booking, there is no fee
+	RuleRescheduledByVenue Rule = "rescheduled_by_venue"
+)
+
+// Outcome is the fee and refund of a cancellation, amounts are in minor currency unit
+type Outcome struct {
+	// PolicyID is zero when the resource has no policy
+	PolicyID   int
+	Rule       Rule
+	FeePercent int
+	Fee        int64
+	// Refund is the part of the price given back, zero for a reschedule
+	Refund int64
+	// FreeUntil is the free cancellation deadline, nil when there is none
+	FreeUntil *time.Time
+}
+
+type CreatePolicy struct {
+	VenueID               int
+	Name                  string
+	NonRefundable         bool
+	FreeCancellationHours int
+	LateFeePercent        int
+	RescheduleFeePercent  int
+}
+
+// UpdatePolicy is partial update of the policy, nil field is left untouched
+type UpdatePolicy struct {
+	Name                  *string
+	NonRefundable         *bool
+	FreeCancellationHours *int
+	LateFeePercent        *int
+	RescheduleFeePercent  *int
+}
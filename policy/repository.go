@@ -0,0 +1,56 @@
+package policy
+
+import (
+	"context"
+
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	CreatePolicy(ctx context.Context, policy *Policy) error
+	GetPolicy(ctx context.Context, id int) (Policy, error)
+	UpdatePolicy(ctx context.Context, id int, fields map[string]interface{}) error
+	DeletePolicy(ctx context.Context, id int) error
+	ListPolicies(ctx context.Context, venueID int) ([]Policy, error)
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+func (r *repository) CreatePolicy(ctx context.Context, policy *Policy) error {
+	return r.db.WithContext(ctx).Create(policy).Error
+}
+
+// GetPolicy return gorm.ErrRecordNotFound if the policy does not exist
+func (r *repository) GetPolicy(ctx context.Context, id int) (Policy, error) {
+	var policy Policy
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&policy).Error
+	return policy, err
+}
+
+func (r *repository) UpdatePolicy(ctx context.Context, id int, fields map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&Policy{}).Where("id = ?", id).Updates(fields).Error
+}
+
+func (r *repository) DeletePolicy(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&Policy{}).Error
+}
+
+func (r *repository) ListPolicies(ctx context.Context, venueID int) ([]Policy, error) {
+	var policies []Policy
+	err := r.db.WithContext(ctx).
+		Where("venue_id = ?", venueID).
+		Order("id").
+		Find(&policies).Error
+	return policies, err
+}
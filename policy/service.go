@@ -0,0 +1,207 @@
+package policy
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/venue"
+	"gorm.io/gorm"
+)
+
+const (
+	maxNameLength = 100
+	// maxFreeCancellationHours is 30 days
+	maxFreeCancellationHours = 720
+)
+
+type service struct {
+	repo      Repository
+	venues    venue.Service
+	resources Resources
+}
+
+// Resources is the part of resource.Repository the policies need, the resource
+// package cannot be imported since it depend on this one
+type Resources interface {
+	// DetachPolicy leave the resources using the policy without policy
+	DetachPolicy(ctx context.Context, policyID int) error
+}
+
+type Service interface {
+	CreatePolicy(ctx context.Context, actor server.AuthInfo, req CreatePolicy) (Policy, error)
+	GetPolicy(ctx context.Context, id int) (Policy, error)
+	UpdatePolicy(ctx context.Context, actor server.AuthInfo, id int, req UpdatePolicy) (Policy, error)
+	// DeletePolicy remove the policy, resources using it are left without policy
+	DeletePolicy(ctx context.Context, actor server.AuthInfo, id int) error
+	ListPolicies(ctx context.Context, venueID int) ([]Policy, error)
+}
+
+func NewService(repo Repository, venues venue.Service, resources Resources) Service {
+	return &service{
+		repo:      repo,
+		venues:    venues,
+		resources: resources,
+	}
+}
+
+// CreatePolicy add cancellation policy to a venue managed by the actor
+func (s *service) CreatePolicy(ctx context.Context, actor server.AuthInfo, req CreatePolicy) (Policy, error) {
+	v, err := s.venues.GetManagedVenue(ctx, actor, req.VenueID)
+	if err != nil {
+		return Policy{}, err
+	}
+	if v.ArchivedAt != nil {
+		return Policy{}, venue.ErrVenueArchived
+	}
+
+	policy := Policy{
+		VenueID:               v.ID,
+		Name:                  strings.TrimSpace(req.Name),
+		NonRefundable:         req.NonRefundable,
+		FreeCancellationHours: req.FreeCancellationHours,
+		LateFeePercent:        req.LateFeePercent,
+		RescheduleFeePercent:  req.RescheduleFeePercent,
+	}
+	if err := validateName(policy.Name); err != nil {
+		return Policy{}, err
+	}
+	if err := validateFreeCancellationHours(policy.FreeCancellationHours); err != nil {
+		return Policy{}, err
+	}
+	if err := validateLateFeePercent(policy.LateFeePercent); err != nil {
+		return Policy{}, err
+	}
+	if err := validateRescheduleFeePercent(policy.RescheduleFeePercent); err != nil {
+		return Policy{}, err
+	}
+
+	if err := s.repo.CreatePolicy(ctx, &policy); err != nil {
+		return Policy{}, internalError(err)
+	}
+	return policy, nil
+}
+
+func (s *service) GetPolicy(ctx context.Context, id int) (Policy, error) {
+	policy, err := s.repo.GetPolicy(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Policy{}, ErrPolicyNotFound
+	}
+	if err != nil {
+		return Policy{}, internalError(err)
+	}
+	return policy, nil
+}
+
+// UpdatePolicy validate and save the given fields, the change apply to every
+// later cancellation and reschedule including those of existing bookings
+func (s *service) UpdatePolicy(ctx context.Context, actor server.AuthInfo, id int, req UpdatePolicy) (Policy, error) {
+	if _, err := s.getManagedPolicy(ctx, actor, id); err != nil {
+		return Policy{}, err
+	}
+
+	fields := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := validateName(name); err != nil {
+			return Policy{}, err
+		}
+		fields["name"] = name
+	}
+	if req.NonRefundable != nil {
+		fields["non_refundable"] = *req.NonRefundable
+	}
+	if req.FreeCancellationHours != nil {
+		if err := validateFreeCancellationHours(*req.FreeCancellationHours); err != nil {
+			return Policy{}, err
+		}
+		fields["free_cancellation_hours"] = *req.FreeCancellationHours
+	}
+	if req.LateFeePercent != nil {
+		if err := validateLateFeePercent(*req.LateFeePercent); err != nil {
+			return Policy{}, err
+		}
+		fields["late_fee_percent"] = *req.LateFeePercent
+	}
+	if req.RescheduleFeePercent != nil {
+		if err := validateRescheduleFeePercent(*req.RescheduleFeePercent); err != nil {
+			return Policy{}, err
+		}
+		fields["reschedule_fee_percent"] = *req.RescheduleFeePercent
+	}
+
+	if len(fields) > 0 {
+		if err := s.repo.UpdatePolicy(ctx, id, fields); err != nil {
+			return Policy{}, internalError(err)
+		}
+	}
+	return s.GetPolicy(ctx, id)
+}
+
+// DeletePolicy detach the policy before removing it, a resource attached in the
+// meantime refer to a missing policy which is the same as having no policy
+func (s *service) DeletePolicy(ctx context.Context, actor server.AuthInfo, id int) error {
+	if _, err := s.getManagedPolicy(ctx, actor, id); err != nil {
+		return err
+	}
+	if err := s.resources.DetachPolicy(ctx, id); err != nil {
+		return internalError(err)
+	}
+	if err := s.repo.DeletePolicy(ctx, id); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+func (s *service) ListPolicies(ctx context.Context, venueID int) ([]Policy, error) {
+	if _, err := s.venues.GetVenue(ctx, venueID); err != nil {
+		return nil, err
+	}
+	policies, err := s.repo.ListPolicies(ctx, venueID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return policies, nil
+}
+
+// getManagedPolicy return the policy if the actor manage its venue
+func (s *service) getManagedPolicy(ctx context.Context, actor server.AuthInfo, id int) (Policy, error) {
+	policy, err := s.GetPolicy(ctx, id)
+	if err != nil {
+		return Policy{}, err
+	}
+	if _, err := s.venues.GetManagedVenue(ctx, actor, policy.VenueID); err != nil {
+		return Policy{}, err
+	}
+	return policy, nil
+}
+
+func validateName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func validateFreeCancellationHours(hours int) error {
+	if hours < 0 || hours > maxFreeCancellationHours {
+		return ErrInvalidFreeCancellationHours
+	}
+	return nil
+}
+
+func validateLateFeePercent(percent int) error {
+	if percent < 0 || percent > 100 {
+		return ErrInvalidLateFeePercent
+	}
+	return nil
+}
+
+func validateRescheduleFeePercent(percent int) error {
+	if percent < 0 || percent > 100 {
+		return ErrInvalidRescheduleFeePercent
+	}
+	return nil
+}
@@ -0,0 +1,25 @@
+package resource
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidName      = status.Error(codes.InvalidArgument, "resource name must be 1-100 characters")
+	ErrInvalidType      = status.Error(codes.InvalidArgument, "resource type must be 2-30 lowercase letters, digits or '_', e.g. court")
+	ErrInvalidCapacity  = status.Error(codes.InvalidArgument, "resource capacity must be between 1 and 10000")
+	ErrInvalidBuffer    = status.Error(codes.InvalidArgument, "resource buffers must be between 0 and 240 minutes")
+	ErrInvalidPrice     = status.Error(codes.InvalidArgument, "hourly rate must be between 0 and 1000000000 with a 3 letter currency code such as IDR")
+	ErrInvalidTags      = status.Error(codes.InvalidArgument, "at most 20 tags of 1-30 lowercase letters, digits, '-' or '_' are allowed")
+	ErrResourceNotFound = status.Error(codes.NotFound, "resource not found")
+	ErrHasBookings      = status.Error(codes.FailedPrecondition, "resource has upcoming bookings, cancel or move them first")
+	ErrInternal         = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[resource] %v", err)
+	return ErrInternal
+}
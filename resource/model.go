@@ -0,0 +1,133 @@
+package resource
+
+import (
+	"time"
+
+	"github.com/booking-man-be/venue"
+	"gorm.io/gorm"
+)
+
+// Resource is something bookable in a venue such as a room, court or table
+type Resource struct {
+	ID          int    `gorm:"primary_key"`
+	VenueID     int    `gorm:"not null;index"`
+	Name        string `gorm:"type:varchar(100);not null"`
+	Description string `gorm:"type:text"`
+	// Type is lowercase kind of the resource, e.g. room, court or table
+	Type string `gorm:"type:varchar(30);not null;index"`
+	// Capacity is the largest party size the resource can take
+	Capacity int `gorm:"not null"`
+	// BufferBefore and BufferAfter are setup and turnover time kept free
+	// around every booking of the resource | minutes unit
+	BufferBefore int `gorm:"not null;default:0"`
+	BufferAfter  int `gorm:"not null;default:0"`
+	// RequiresConfirmation create bookings as pending until the venue confirm them
+	RequiresConfirmation bool `gorm:"not null"`
+	// HourlyRate is the price of one hour in minor unit of the currency, e.g. cents
+	HourlyRate int64  `gorm:"not null;default:0"`
+	Currency   string `gorm:"type:char(3);not null;default:''"`
+	// CancellationPolicyID is the policy of customer cancellations, nil refund everything
+	CancellationPolicyID *int `gorm:"index"`
+	// Active resource can be booked
+	Active bool  `gorm:"not null"`
+	Tags   []Tag `gorm:"foreignKey:ResourceID"`
+	// OpeningHours override the weekly opening hours of the venue when it is not empty
+	OpeningHours []OpeningHour `gorm:"foreignKey:ResourceID"`
+
+	CreatedAt time.Time      `gorm:"not null"`
+	UpdatedAt time.Time      `gorm:"not null"`
+	DeletedAt gorm.DeletedAt `gorm:"index"`
+}
+
+// Tag is free form label of the resource used for filtering, e.g. outdoor
+type Tag struct {
+	ResourceID int    `gorm:"primary_key;autoIncrement:false"`
+	Tag        string `gorm:"type:varchar(30);primary_key;index"`
+}
+
+func (Tag) TableName() string {
+	return "resource_tag"
+}
+
+// OpeningHour is weekly opening interval of the resource
+type OpeningHour struct {
+	ID                int `gorm:"primary_key"`
+	ResourceID        int `gorm:"not null;index"`
+	venue.WeeklyHours `gorm:"embedded"`
+}
+
+func (OpeningHour) TableName() string {
+	return "resource_opening_hour"
+}
+
+// Buffer return the time to keep free between two bookings of the resource
+func (r Resource) Buffer() time.Duration {
+	return time.Duration(r.BufferBefore+r.BufferAfter) * time.Minute
+}
+
+// Price return the price of booking the resource for the duration rounded to the minor currency unit
+func (r Resource) Price(duration time.Duration) int64 {
+	minutes := int64(duration / time.Minute)
+	return (r.HourlyRate*minutes + 30) / 60
+}
+
+// TagNames return the tags as strings
+func (r Resource) TagNames() []string {
+	names := make([]string, 0, len(r.Tags))
+	for _, t := range r.Tags {
+		names = append(names, t.Tag)
+	}
+	return names
+}
+
+// CreateResource is the input needed to add a resource to a venue
+type CreateResource struct {
+	VenueID      int
+	Name         string
+	Description  string
+	Type         string
+	Capacity     int
+	BufferBefore int
+	BufferAfter  int
+	Active       bool
+	Tags         []string
+	OpeningHours []venue.WeeklyHours
+
+	RequiresConfirmation bool
+	HourlyRate           int64
+	Currency             string
+	// CancellationPolicyID is zero for no policy
+	CancellationPolicyID int
+}
+
+// UpdateResource is partial update of the resource, nil field is left untouched,
+// Tags and OpeningHours replace the existing ones when they are set
+type UpdateResource struct {
+	Name         *string
+	Description  *string
+	Type         *string
+	Capacity     *int
+	BufferBefore *int
+	BufferAfter  *int
+	Active       *bool
+	Tags         *[]string
+	OpeningHours *[]venue.WeeklyHours
+
+	RequiresConfirmation *bool
+	HourlyRate           *int64
+	Currency             *string
+	// CancellationPolicyID set to zero detach the policy
+	CancellationPolicyID *int
+}
+
+// ListResources filter resources of venues which are not archived,
+// zero value field is not filtered
+type ListResources struct {
+	VenueID int
+	Type    string
+	Tag     string
+	// IncludeInactive also return resources which can not be booked
+	IncludeInactive bool
+	Page            int
+	PageSize        int
+}
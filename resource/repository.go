@@ -0,0 +1,189 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/booking-man-be/venue"
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// errHasBookings is returned when the deleted resource still has bookings to serve
+var errHasBookings = errors.New("resource has upcoming bookings")
+
+// upcomingStatuses is the booking statuses which still need the resource once
+// booked, the booking package depend on this one so they are repeated here
+var upcomingStatuses = []string{"pending", "confirmed", "checked_in"}
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	CreateResource(ctx context.Context, resource *Resource) error
+	GetResource(ctx context.Context, id int) (Resource, error)
+	UpdateResource(ctx context.Context, id int, fields map[string]interface{}, tags *[]string, openingHours *[]venue.WeeklyHours) error
+	DeleteResource(ctx context.Context, id int, now time.Time) error
+	ListResources(ctx context.Context, filter ListResources) ([]Resource, int64, error)
+	ListActiveResources(ctx context.Context, venueID int, ids []int) ([]Resource, error)
+	DetachPolicy(ctx context.Context, policyID int) error
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+// CreateResource insert the resource together with its tags and opening hours
+func (r *repository) CreateResource(ctx context.Context, resource *Resource) error {
+	return r.db.WithContext(ctx).Create(resource).Error
+}
+
+// GetResource return gorm.ErrRecordNotFound if the resource does not exist or it is deleted
+func (r *repository) GetResource(ctx context.Context, id int) (Resource, error) {
+	var resource Resource
+	err := r.db.WithContext(ctx).
+		Preload("Tags", orderTags).
+		Preload("OpeningHours", orderOpeningHours).
+		Where("id = ?", id).
+		First(&resource).Error
+	return resource, err
+}
+
+// UpdateResource save the changed fields, tags and openingHours replace the existing ones when they are not nil
+func (r *repository) UpdateResource(ctx context.Context, id int, fields map[string]interface{}, tags *[]string, openingHours *[]venue.WeeklyHours) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(fields) > 0 {
+			if err := tx.Model(&Resource{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return err
+			}
+		}
+
+		if tags != nil {
+			if err := tx.Where("resource_id = ?", id).Delete(&Tag{}).Error; err != nil {
+				return err
+			}
+			if len(*tags) > 0 {
+				rows := make([]Tag, 0, len(*tags))
+				for _, tag := range *tags {
+					rows = append(rows, Tag{ResourceID: id, Tag: tag})
+				}
+				if err := tx.Create(&rows).Error; err != nil {
+					return err
+				}
+			}
+		}
+
+		if openingHours != nil {
+			if err := tx.Where("resource_id = ?", id).Delete(&OpeningHour{}).Error; err != nil {
+				return err
+			}
+			if len(*openingHours) > 0 {
+				rows := make([]OpeningHour, 0, len(*openingHours))
+				for _, h := range *openingHours {
+					rows = append(rows, OpeningHour{ResourceID: id, WeeklyHours: h})
+				}
+				if err := tx.Create(&rows).Error; err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+}
+
+// DeleteResource soft delete the resource, it is kept for existing bookings. It return
+// errHasBookings while a booking not ended yet need the resource, the row is locked
+// like on booking creation so no booking is added meanwhile
+func (r *repository) DeleteResource(ctx context.Context, id int, now time.Time) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Clauses(clause.Locking{Strength: "UPDATE"}).
+			Select("id").
+			Take(&Resource{}, id).Error
+		if err != nil {
+			return err
+		}
+
+		var count int64
+		err = tx.Table(tx.NamingStrategy.TableName("Booking")).
+			Where("resource_id = ? AND end_at > ? AND status IN ?", id, now, upcomingStatuses).
+			Count(&count).Error
+		if err != nil {
+			return err
+		}
+		if count > 0 {
+			return errHasBookings
+		}
+		return tx.Delete(&Resource{}, id).Error
+	})
+}
+
+// DetachPolicy leave the resources using the cancellation policy without policy,
+// deleted resources included
+func (r *repository) DetachPolicy(ctx context.Context, policyID int) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&Resource{}).
+		Where("cancellation_policy_id = ?", policyID).
+		Update("cancellation_policy_id", nil).Error
+}
+
+// ListResources return one page of resources ordered by ID and the total count
+func (r *repository) ListResources(ctx context.Context, filter ListResources) ([]Resource, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Resource{}).
+		Where("venue_id IN (?)", r.db.Model(&venue.Venue{}).Select("id").Where("archived_at IS NULL"))
+	if filter.VenueID != 0 {
+		query = query.Where("venue_id = ?", filter.VenueID)
+	}
+	if filter.Type != "" {
+		query = query.Where("type = ?", filter.Type)
+	}
+	if filter.Tag != "" {
+		query = query.Where("id IN (?)", r.db.Model(&Tag{}).Select("resource_id").Where("tag = ?", filter.Tag))
+	}
+	if !filter.IncludeInactive {
+		query = query.Where("active = ?", true)
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var resources []Resource
+	err := query.
+		Preload("Tags", orderTags).
+		Preload("OpeningHours", orderOpeningHours).
+		Order("id").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&resources).Error
+	return resources, total, err
+}
+
+// ListActiveResources return active resources of the venue, only the given ones when ids is not empty
+func (r *repository) ListActiveResources(ctx context.Context, venueID int, ids []int) ([]Resource, error) {
+	query := r.db.WithContext(ctx).Where("venue_id = ? AND active = ?", venueID, true)
+	if len(ids) > 0 {
+		query = query.Where("id IN ?", ids)
+	}
+
+	var resources []Resource
+	err := query.
+		Preload("OpeningHours", orderOpeningHours).
+		Order("id").
+		Find(&resources).Error
+	return resources, err
+}
+
+func orderTags(db *gorm.DB) *gorm.DB {
+	return db.Order("tag")
+}
+
+func orderOpeningHours(db *gorm.DB) *gorm.DB {
+	return db.Order("weekday, open_minute")
+}
@@ -0,0 +1,369 @@
+package resource
+
+import (
+	"context"
+	"errors"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/policy"
+	"github.com/booking-man-be/venue"
+	"gorm.io/gorm"
+)
+
+const (
+	maxNameLength   = 100
+	maxCapacity     = 10000
+	maxTags         = 20
+	maxBuffer       = 240
+	maxHourlyRate   = 1000000000
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var (
+	typeRegex     = regexp.MustCompile(`^[a-z][a-z0-9_]{1,29}$`)
+	tagRegex      = regexp.MustCompile(`^[a-z0-9][a-z0-9_-]{0,29}$`)
+	currencyRegex = regexp.MustCompile(`^[A-Z]{3}$`)
+)
+
+type service struct {
+	repo     Repository
+	venues   venue.Service
+	policies policy.Service
+}
+
+type Service interface {
+	CreateResource(ctx context.Context, actor server.AuthInfo, req CreateResource) (Resource, error)
+	GetResource(ctx context.Context, id int) (Resource, error)
+	UpdateResource(ctx context.Context, actor server.AuthInfo, id int, req UpdateResource) (Resource, error)
+	DeleteResource(ctx context.Context, actor server.AuthInfo, id int) error
+	ListResources(ctx context.Context, filter ListResources) ([]Resource, int64, error)
+	// ListActiveResources return active resources of the venue, only the given ones when ids is not empty
+	ListActiveResources(ctx context.Context, venueID int, ids []int) ([]Resource, error)
+}
+
+func NewService(repo Repository, venues venue.Service, policies policy.Service) Service {
+	return &service{
+		repo:     repo,
+		venues:   venues,
+		policies: policies,
+	}
+}
+
+// CreateResource add new resource to a venue managed by the actor
+func (s *service) CreateResource(ctx context.Context, actor server.AuthInfo, req CreateResource) (Resource, error) {
+	v, err := s.venues.GetManagedVenue(ctx, actor, req.VenueID)
+	if err != nil {
+		return Resource{}, err
+	}
+	if v.ArchivedAt != nil {
+		return Resource{}, venue.ErrVenueArchived
+	}
+
+	resource := Resource{
+		VenueID:     v.ID,
+		Name:        strings.TrimSpace(req.Name),
+		Description: strings.TrimSpace(req.Description),
+		Type:        strings.ToLower(strings.TrimSpace(req.Type)),
+		Capacity:    req.Capacity,
+		Active:      req.Active,
+
+		BufferBefore: req.BufferBefore,
+		BufferAfter:  req.BufferAfter,
+
+		RequiresConfirmation: req.RequiresConfirmation,
+		HourlyRate:           req.HourlyRate,
+		Currency:             strings.ToUpper(strings.TrimSpace(req.Currency)),
+	}
+	if err := validateName(resource.Name); err != nil {
+		return Resource{}, err
+	}
+	if err := validateType(resource.Type); err != nil {
+		return Resource{}, err
+	}
+	if err := validateCapacity(resource.Capacity); err != nil {
+		return Resource{}, err
+	}
+	if err := validateBuffer(resource.BufferBefore); err != nil {
+		return Resource{}, err
+	}
+	if err := validateBuffer(resource.BufferAfter); err != nil {
+		return Resource{}, err
+	}
+	if err := validatePrice(resource.HourlyRate, resource.Currency); err != nil {
+		return Resource{}, err
+	}
+	if req.CancellationPolicyID != 0 {
+		if err := s.checkPolicy(ctx, v.ID, req.CancellationPolicyID); err != nil {
+			return Resource{}, err
+		}
+		resource.CancellationPolicyID = &req.CancellationPolicyID
+	}
+	tags, err := normalizeTags(req.Tags)
+	if err != nil {
+		return Resource{}, err
+	}
+	if err := venue.ValidateWeeklyHours(req.OpeningHours); err != nil {
+		return Resource{}, err
+	}
+	for _, tag := range tags {
+		resource.Tags = append(resource.Tags, Tag{Tag: tag})
+	}
+	for _, h := range req.OpeningHours {
+		resource.OpeningHours = append(resource.OpeningHours, OpeningHour{WeeklyHours: h})
+	}
+
+	if err := s.repo.CreateResource(ctx, &resource); err != nil {
+		return Resource{}, internalError(err)
+	}
+	return s.GetResource(ctx, resource.ID)
+}
+
+func (s *service) GetResource(ctx context.Context, id int) (Resource, error) {
+	resource, err := s.repo.GetResource(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Resource{}, ErrResourceNotFound
+	}
+	if err != nil {
+		return Resource{}, internalError(err)
+	}
+	return resource, nil
+}
+
+// UpdateResource validate and save the given fields, resource of archived venue can not be changed
+func (s *service) UpdateResource(ctx context.Context, actor server.AuthInfo, id int, req UpdateResource) (Resource, error) {
+	resource, v, err := s.getManagedResource(ctx, actor, id)
+	if err != nil {
+		return Resource{}, err
+	}
+	if v.ArchivedAt != nil {
+		return Resource{}, venue.ErrVenueArchived
+	}
+
+	fields := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := validateName(name); err != nil {
+			return Resource{}, err
+		}
+		fields["name"] = name
+	}
+	if req.Description != nil {
+		fields["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.Type != nil {
+		resourceType := strings.ToLower(strings.TrimSpace(*req.Type))
+		if err := validateType(resourceType); err != nil {
+			return Resource{}, err
+		}
+		fields["type"] = resourceType
+	}
+	if req.Capacity != nil {
+		if err := validateCapacity(*req.Capacity); err != nil {
+			return Resource{}, err
+		}
+		fields["capacity"] = *req.Capacity
+	}
+	if req.BufferBefore != nil {
+		if err := validateBuffer(*req.BufferBefore); err != nil {
+			return Resource{}, err
+		}
+		fields["buffer_before"] = *req.BufferBefore
+	}
+	if req.BufferAfter != nil {
+		if err := validateBuffer(*req.BufferAfter); err != nil {
+			return Resource{}, err
+		}
+		fields["buffer_after"] = *req.BufferAfter
+	}
+	if req.RequiresConfirmation != nil {
+		fields["requires_confirmation"] = *req.RequiresConfirmation
+	}
+	if req.HourlyRate != nil || req.Currency != nil {
+		hourlyRate, currency := resource.HourlyRate, resource.Currency
+		if req.HourlyRate != nil {
+			hourlyRate = *req.HourlyRate
+		}
+		if req.Currency != nil {
+			currency = strings.ToUpper(strings.TrimSpace(*req.Currency))
+		}
+		if err := validatePrice(hourlyRate, currency); err != nil {
+			return Resource{}, err
+		}
+		fields["hourly_rate"] = hourlyRate
+		fields["currency"] = currency
+	}
+	if req.CancellationPolicyID != nil {
+		if *req.CancellationPolicyID == 0 {
+			fields["cancellation_policy_id"] = nil
+		} else {
+			if err := s.checkPolicy(ctx, resource.VenueID, *req.CancellationPolicyID); err != nil {
+				return Resource{}, err
+			}
+			fields["cancellation_policy_id"] = *req.CancellationPolicyID
+		}
+	}
+	if req.Active != nil {
+		fields["active"] = *req.Active
+	}
+	var tags *[]string
+	if req.Tags != nil {
+		normalized, err := normalizeTags(*req.Tags)
+		if err != nil {
+			return Resource{}, err
+		}
+		tags = &normalized
+	}
+	if req.OpeningHours != nil {
+		if err := venue.ValidateWeeklyHours(*req.OpeningHours); err != nil {
+			return Resource{}, err
+		}
+	}
+
+	if len(fields) > 0 || tags != nil || req.OpeningHours != nil {
+		if err := s.repo.UpdateResource(ctx, id, fields, tags, req.OpeningHours); err != nil {
+			return Resource{}, internalError(err)
+		}
+		s.venues.ScheduleChanged(ctx, v.ID)
+	}
+	return s.GetResource(ctx, id)
+}
+
+// DeleteResource remove the resource from its venue, it fail with ErrHasBookings
+// while bookings of the resource did not end
+func (s *service) DeleteResource(ctx context.Context, actor server.AuthInfo, id int) error {
+	resource, _, err := s.getManagedResource(ctx, actor, id)
+	if err != nil {
+		return err
+	}
+	err = s.repo.DeleteResource(ctx, id, time.Now())
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrResourceNotFound
+	}
+	if errors.Is(err, errHasBookings) {
+		return ErrHasBookings
+	}
+	if err != nil {
+		return internalError(err)
+	}
+	s.venues.ScheduleChanged(ctx, resource.VenueID)
+	return nil
+}
+
+// ListResources return one page of resources and the total count
+func (s *service) ListResources(ctx context.Context, filter ListResources) ([]Resource, int64, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	filter.Type = strings.ToLower(strings.TrimSpace(filter.Type))
+	filter.Tag = strings.ToLower(strings.TrimSpace(filter.Tag))
+
+	resources, total, err := s.repo.ListResources(ctx, filter)
+	if err != nil {
+		return nil, 0, internalError(err)
+	}
+	return resources, total, nil
+}
+
+func (s *service) ListActiveResources(ctx context.Context, venueID int, ids []int) ([]Resource, error) {
+	resources, err := s.repo.ListActiveResources(ctx, venueID, ids)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return resources, nil
+}
+
+// getManagedResource return the resource and its venue if the actor manage the venue
+func (s *service) getManagedResource(ctx context.Context, actor server.AuthInfo, id int) (Resource, venue.Venue, error) {
+	resource, err := s.GetResource(ctx, id)
+	if err != nil {
+		return Resource{}, venue.Venue{}, err
+	}
+	v, err := s.venues.GetManagedVenue(ctx, actor, resource.VenueID)
+	if err != nil {
+		return Resource{}, venue.Venue{}, err
+	}
+	return resource, v, nil
+}
+
+func validateName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func validateType(resourceType string) error {
+	if !typeRegex.MatchString(resourceType) {
+		return ErrInvalidType
+	}
+	return nil
+}
+
+func validateCapacity(capacity int) error {
+	if capacity < 1 || capacity > maxCapacity {
+		return ErrInvalidCapacity
+	}
+	return nil
+}
+
+// checkPolicy make sure the cancellation policy belongs to the venue
+func (s *service) checkPolicy(ctx context.Context, venueID, policyID int) error {
+	p, err := s.policies.GetPolicy(ctx, policyID)
+	if err != nil {
+		return err
+	}
+	if p.VenueID != venueID {
+		return policy.ErrPolicyNotFound
+	}
+	return nil
+}
+
+// validatePrice require a currency for paid resources
+func validatePrice(hourlyRate int64, currency string) error {
+	if hourlyRate < 0 || hourlyRate > maxHourlyRate {
+		return ErrInvalidPrice
+	}
+	if (hourlyRate > 0 || currency != "") && !currencyRegex.MatchString(currency) {
+		return ErrInvalidPrice
+	}
+	return nil
+}
+
+func validateBuffer(minutes int) error {
+	if minutes < 0 || minutes > maxBuffer {
+		return ErrInvalidBuffer
+	}
+	return nil
+}
+
+// normalizeTags lowercase and deduplicate the tags
+func normalizeTags(tags []string) ([]string, error) {
+	seen := make(map[string]bool, len(tags))
+	normalized := make([]string, 0, len(tags))
+	for _, tag := range tags {
+		tag = strings.ToLower(strings.TrimSpace(tag))
+		if !tagRegex.MatchString(tag) {
+			return nil, ErrInvalidTags
+		}
+		if seen[tag] {
+			continue
+		}
+		seen[tag] = true
+		normalized = append(normalized, tag)
+	}
+	if len(normalized) > maxTags {
+		return nil, ErrInvalidTags
+	}
+	return normalized, nil
+}
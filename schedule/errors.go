@@ -0,0 +1,23 @@
+package schedule
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidDateRange   = status.Error(codes.InvalidArgument, "dates must be YYYY-MM-DD with end date not before start date and at most 366 days apart")
+	ErrInvalidKind        = status.Error(codes.InvalidArgument, "exception kind must be closed or open")
+	ErrInvalidHours       = status.Error(codes.InvalidArgument, "exception hours must be HH:MM with opening before closing, open exception requires hours")
+	ErrInvalidReason      = status.Error(codes.InvalidArgument, "reason must be at most 255 characters")
+	ErrResourceNotInVenue = status.Error(codes.InvalidArgument, "resource does not belong to the venue")
+	ErrExceptionNotFound  = status.Error(codes.NotFound, "schedule exception not found")
+	ErrInternal           = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[schedule] %v", err)
+	return ErrInternal
+}
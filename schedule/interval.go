@@ -0,0 +1,108 @@
+package schedule
+
+import (
+	"sort"
+	"time"
+
+	"github.com/booking-man-be/venue"
+)
+
+// Expand turn weekly opening hours and exceptions into concrete open intervals
+// for every date from startDate to endDate inclusive, dates are midnight in loc.
+// Local time which does not exist because of daylight saving is normalized by time.Date
+func Expand(loc *time.Location, weekly []venue.WeeklyHours, exceptions []Exception, startDate, endDate time.Time) []Interval {
+	var open []Interval
+	for day := startDate; !day.After(endDate); day = day.AddDate(0, 0, 1) {
+		date := day.Format(DateLayout)
+		var regular, closed, extra []Interval
+		for _, h := range weekly {
+			if h.Weekday == day.Weekday() {
+				regular = append(regular, dayInterval(loc, day, h.OpenMinute, h.CloseMinute))
+			}
+		}
+		for _, e := range exceptions {
+			if date < e.StartDate || date > e.EndDate {
+				continue
+			}
+			if e.Kind == KindClosed {
+				closed = append(closed, dayInterval(loc, day, e.OpenMinute, e.CloseMinute))
+			} else {
+				extra = append(extra, dayInterval(loc, day, e.OpenMinute, e.CloseMinute))
+			}
+		}
+
+		open = append(open, Subtract(Merge(regular), Merge(closed))...)
+		open = append(open, extra...)
+	}
+	// join intervals continuing over midnight
+	return Merge(open)
+}
+
+// Merge sort the intervals and join the overlapping or touching ones
+func Merge(intervals []Interval) []Interval {
+	if len(intervals) == 0 {
+		return nil
+	}
+	sorted := make([]Interval, len(intervals))
+	copy(sorted, intervals)
+	sort.Slice(sorted, func(i, j int) bool {
+		return sorted[i].Start.Before(sorted[j].Start)
+	})
+
+	merged := []Interval{sorted[0]}
+	for _, interval := range sorted[1:] {
+		last := &merged[len(merged)-1]
+		if interval.Start.After(last.End) {
+			merged = append(merged, interval)
+			continue
+		}
+		if interval.End.After(last.End) {
+			last.End = interval.End
+		}
+	}
+	return merged
+}
+
+// Subtract remove every part of intervals covered by cuts, both must be
+// sorted and non overlapping e.g. the result of Merge
+func Subtract(intervals, cuts []Interval) []Interval {
+	var result []Interval
+	j := 0
+	for _, interval := range intervals {
+		start := interval.Start
+		for j < len(cuts) && !cuts[j].End.After(start) {
+			j++
+		}
+		for k := j; k < len(cuts) && cuts[k].Start.Before(interval.End); k++ {
+			if cuts[k].Start.After(start) {
+				result = append(result, Interval{Start: start, End: cuts[k].Start})
+			}
+			if cuts[k].End.After(start) {
+				start = cuts[k].End
+			}
+		}
+		if start.Before(interval.End) {
+			result = append(result, Interval{Start: start, End: interval.End})
+		}
+	}
+	return result
+}
+
+// Covers check whether one of the merged intervals contain the whole interval
+func Covers(intervals []Interval, interval Interval) bool {
+	for _, i := range intervals {
+		if !i.Start.After(interval.Start) && !i.End.Before(interval.End) {
+			return true
+		}
+	}
+	return false
+}
+
+// dayInterval convert minutes since midnight of the date into absolute interval
+func dayInterval(loc *time.Location, day time.Time, openMinute, closeMinute int) Interval {
+	y, m, d := day.Date()
+	return Interval{
+		Start: time.Date(y, m, d, openMinute/60, openMinute%60, 0, 0, loc),
+		End:   time.Date(y, m, d, closeMinute/60, closeMinute%60, 0, 0, loc),
+	}
+}
@@ -0,0 +1,79 @@
+package schedule
+
+import (
+	"time"
+)
+
+// DateLayout is the format of calendar dates in the venue time zone
+const DateLayout = "2006-01-02"
+
+// ExceptionKind decide how an exception change the weekly opening hours
+type ExceptionKind string
+
+const (
+	// KindClosed close the venue or resource during the exception, e.g. holiday or maintenance
+	KindClosed ExceptionKind = "closed"
+	// KindOpen open the venue or resource during the exception, e.g. special late opening
+	KindOpen ExceptionKind = "open"
+)
+
+// Exception change the weekly opening hours on a range of dates. Every day
+// from StartDate to EndDate the interval from OpenMinute to CloseMinute is
+// closed or opened, closures are applied before openings so a holiday with
+// shorter hours is a whole day closure plus an opening
+type Exception struct {
+	ID      int `gorm:"primary_key"`
+	VenueID int `gorm:"not null;index:idx_schedule_exception_venue_date"`
+	// ResourceID limit the exception to one resource, nil apply it to the whole venue
+	ResourceID *int `gorm:"index"`
+	// StartDate and EndDate are inclusive dates formatted with DateLayout
+	StartDate string        `gorm:"type:char(10);not null;index:idx_schedule_exception_venue_date"`
+	EndDate   string        `gorm:"type:char(10);not null"`
+	Kind      ExceptionKind `gorm:"type:varchar(10);not null"`
+	// OpenMinute and CloseMinute are minutes since midnight, 0 and 1440 cover the whole day
+	OpenMinute  int       `gorm:"not null"`
+	CloseMinute int       `gorm:"not null"`
+	Reason      string    `gorm:"type:varchar(255);not null;default:''"`
+	CreatedBy   int       `gorm:"not null"`
+	CreatedAt   time.Time `gorm:"not null"`
+}
+
+func (Exception) TableName() string {
+	return "schedule_exception"
+}
+
+// IsValid check whether the kind is known
+func (k ExceptionKind) IsValid() bool {
+	return k == KindClosed || k == KindOpen
+}
+
+// Interval is half open time range [Start, End)
+type Interval struct {
+	Start time.Time
+	End   time.Time
+}
+
+// Duration return length of the interval
+func (i Interval) Duration() time.Duration {
+	return i.End.Sub(i.Start)
+}
+
+// CreateException is the input needed to add an exception
+type CreateException struct {
+	VenueID    int
+	ResourceID int
+	StartDate  string
+	EndDate    string
+	Kind       ExceptionKind
+	// OpenMinute and CloseMinute are both zero for whole day
+	OpenMinute  int
+	CloseMinute int
+	Reason      string
+}
+
+// Schedule is the concrete open intervals of a venue or resource
+type Schedule struct {
+	// Timezone is IANA time zone of the venue
+	Timezone  string
+	Intervals []Interval
+}
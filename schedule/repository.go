@@ -0,0 +1,52 @@
+package schedule
+
+import (
+	"context"
+
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	CreateException(ctx context.Context, exception *Exception) error
+	GetException(ctx context.Context, id int) (Exception, error)
+	DeleteException(ctx context.Context, id int) error
+	ListExceptions(ctx context.Context, venueID int, startDate, endDate string) ([]Exception, error)
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+func (r *repository) CreateException(ctx context.Context, exception *Exception) error {
+	return r.db.WithContext(ctx).Create(exception).Error
+}
+
+// GetException return gorm.ErrRecordNotFound if the exception does not exist
+func (r *repository) GetException(ctx context.Context, id int) (Exception, error) {
+	var exception Exception
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&exception).Error
+	return exception, err
+}
+
+func (r *repository) DeleteException(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Where("id = ?", id).Delete(&Exception{}).Error
+}
+
+// ListExceptions return exceptions of the venue and all its resources overlapping the dates
+func (r *repository) ListExceptions(ctx context.Context, venueID int, startDate, endDate string) ([]Exception, error) {
+	var exceptions []Exception
+	err := r.db.WithContext(ctx).
+		Where("venue_id = ? AND start_date <= ? AND end_date >= ?", venueID, endDate, startDate).
+		Order("start_date, id").
+		Find(&exceptions).Error
+	return exceptions, err
+}
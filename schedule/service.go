@@ -0,0 +1,250 @@
+package schedule
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/server"
+	"github.com/booking-man-be/resource"
+	"github.com/booking-man-be/venue"
+	"gorm.io/gorm"
+)
+
+const (
+	// maxDateRangeDays limit the dates of an exception and of a schedule query
+	maxDateRangeDays = 366
+	maxReasonLength  = 255
+	minutesPerDay    = 24 * 60
+)
+
+type service struct {
+	repo      Repository
+	venues    venue.Service
+	resources resource.Service
+}
+
+type Service interface {
+	CreateException(ctx context.Context, actor server.AuthInfo, req CreateException) (Exception, error)
+	ListExceptions(ctx context.Context, venueID, resourceID int, startDate, endDate string) ([]Exception, error)
+	DeleteException(ctx context.Context, actor server.AuthInfo, id int) error
+	// GetSchedule expand the opening hours of the venue, or of the resource when
+	// resourceID is not zero, into open intervals from startDate to endDate inclusive
+	GetSchedule(ctx context.Context, venueID, resourceID int, startDate, endDate string) (Schedule, error)
+	// GetResourceSchedules expand the opening hours of every resource of the venue
+	// from start to end dates inclusive, which are midnight in the venue time zone
+	GetResourceSchedules(ctx context.Context, v venue.Venue, resources []resource.Resource, start, end time.Time) (map[int][]Interval, error)
+}
+
+func NewService(repo Repository, venues venue.Service, resources resource.Service) Service {
+	return &service{
+		repo:      repo,
+		venues:    venues,
+		resources: resources,
+	}
+}
+
+// CreateException add closure or special opening to a venue managed by the actor
+func (s *service) CreateException(ctx context.Context, actor server.AuthInfo, req CreateException) (Exception, error) {
+	v, err := s.venues.GetManagedVenue(ctx, actor, req.VenueID)
+	if err != nil {
+		return Exception{}, err
+	}
+	if v.ArchivedAt != nil {
+		return Exception{}, venue.ErrVenueArchived
+	}
+	if _, _, err := parseDateRange(time.UTC, req.StartDate, req.EndDate); err != nil {
+		return Exception{}, err
+	}
+	if !req.Kind.IsValid() {
+		return Exception{}, ErrInvalidKind
+	}
+	if req.OpenMinute == 0 && req.CloseMinute == 0 {
+		if req.Kind == KindOpen {
+			return Exception{}, ErrInvalidHours
+		}
+		req.CloseMinute = minutesPerDay
+	}
+	if req.OpenMinute < 0 || req.OpenMinute >= req.CloseMinute || req.CloseMinute > minutesPerDay {
+		return Exception{}, ErrInvalidHours
+	}
+	req.Reason = strings.TrimSpace(req.Reason)
+	if utf8.RuneCountInString(req.Reason) > maxReasonLength {
+		return Exception{}, ErrInvalidReason
+	}
+
+	exception := Exception{
+		VenueID:     v.ID,
+		StartDate:   req.StartDate,
+		EndDate:     req.EndDate,
+		Kind:        req.Kind,
+		OpenMinute:  req.OpenMinute,
+		CloseMinute: req.CloseMinute,
+		Reason:      req.Reason,
+		CreatedBy:   actor.UserID,
+	}
+	if req.ResourceID != 0 {
+		if err := s.checkResource(ctx, v.ID, req.ResourceID); err != nil {
+			return Exception{}, err
+		}
+		exception.ResourceID = &req.ResourceID
+	}
+
+	if err := s.repo.CreateException(ctx, &exception); err != nil {
+		return Exception{}, internalError(err)
+	}
+	s.venues.ScheduleChanged(ctx, v.ID)
+	return exception, nil
+}
+
+// ListExceptions return exceptions overlapping the dates, only the venue wide
+// ones when resourceID is zero
+func (s *service) ListExceptions(ctx context.Context, venueID, resourceID int, startDate, endDate string) ([]Exception, error) {
+	if _, _, err := parseDateRange(time.UTC, startDate, endDate); err != nil {
+		return nil, err
+	}
+	if resourceID != 0 {
+		if err := s.checkResource(ctx, venueID, resourceID); err != nil {
+			return nil, err
+		}
+	}
+
+	exceptions, err := s.repo.ListExceptions(ctx, venueID, startDate, endDate)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return filterExceptions(exceptions, resourceID), nil
+}
+
+// DeleteException remove exception of a venue managed by the actor
+func (s *service) DeleteException(ctx context.Context, actor server.AuthInfo, id int) error {
+	exception, err := s.repo.GetException(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrExceptionNotFound
+	}
+	if err != nil {
+		return internalError(err)
+	}
+	if _, err := s.venues.GetManagedVenue(ctx, actor, exception.VenueID); err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteException(ctx, id); err != nil {
+		return internalError(err)
+	}
+	s.venues.ScheduleChanged(ctx, exception.VenueID)
+	return nil
+}
+
+func (s *service) GetSchedule(ctx context.Context, venueID, resourceID int, startDate, endDate string) (Schedule, error) {
+	v, err := s.venues.GetVenue(ctx, venueID)
+	if err != nil {
+		return Schedule{}, err
+	}
+	loc, err := time.LoadLocation(v.Timezone)
+	if err != nil {
+		return Schedule{}, internalError(err)
+	}
+	start, end, err := parseDateRange(loc, startDate, endDate)
+	if err != nil {
+		return Schedule{}, err
+	}
+
+	weekly := VenueWeeklyHours(v)
+	if resourceID != 0 {
+		r, err := s.resources.GetResource(ctx, resourceID)
+		if err != nil {
+			return Schedule{}, err
+		}
+		if r.VenueID != v.ID {
+			return Schedule{}, ErrResourceNotInVenue
+		}
+		weekly = ResourceWeeklyHours(v, r)
+	}
+
+	exceptions, err := s.repo.ListExceptions(ctx, v.ID, startDate, endDate)
+	if err != nil {
+		return Schedule{}, internalError(err)
+	}
+	return Schedule{
+		Timezone:  v.Timezone,
+		Intervals: Expand(loc, weekly, filterExceptions(exceptions, resourceID), start, end),
+	}, nil
+}
+
+func (s *service) GetResourceSchedules(ctx context.Context, v venue.Venue, resources []resource.Resource, start, end time.Time) (map[int][]Interval, error) {
+	loc := start.Location()
+	exceptions, err := s.repo.ListExceptions(ctx, v.ID, start.Format(DateLayout), end.Format(DateLayout))
+	if err != nil {
+		return nil, internalError(err)
+	}
+
+	schedules := make(map[int][]Interval, len(resources))
+	for _, r := range resources {
+		schedules[r.ID] = Expand(loc, ResourceWeeklyHours(v, r), filterExceptions(exceptions, r.ID), start, end)
+	}
+	return schedules, nil
+}
+
+// checkResource make sure the resource belongs to the venue
+func (s *service) checkResource(ctx context.Context, venueID, resourceID int) error {
+	r, err := s.resources.GetResource(ctx, resourceID)
+	if err != nil {
+		return err
+	}
+	if r.VenueID != venueID {
+		return ErrResourceNotInVenue
+	}
+	return nil
+}
+
+// VenueWeeklyHours return the weekly opening hours of the venue
+func VenueWeeklyHours(v venue.Venue) []venue.WeeklyHours {
+	weekly := make([]venue.WeeklyHours, 0, len(v.OpeningHours))
+	for _, h := range v.OpeningHours {
+		weekly = append(weekly, h.WeeklyHours)
+	}
+	return weekly
+}
+
+// ResourceWeeklyHours return the weekly opening hours of the resource,
+// which are the venue ones unless the resource override them
+func ResourceWeeklyHours(v venue.Venue, r resource.Resource) []venue.WeeklyHours {
+	if len(r.OpeningHours) == 0 {
+		return VenueWeeklyHours(v)
+	}
+	weekly := make([]venue.WeeklyHours, 0, len(r.OpeningHours))
+	for _, h := range r.OpeningHours {
+		weekly = append(weekly, h.WeeklyHours)
+	}
+	return weekly
+}
+
+// filterExceptions keep venue wide exceptions and the ones of the resource
+func filterExceptions(exceptions []Exception, resourceID int) []Exception {
+	var filtered []Exception
+	for _, e := range exceptions {
+		if e.ResourceID == nil || (resourceID != 0 && *e.ResourceID == resourceID) {
+			filtered = append(filtered, e)
+		}
+	}
+	return filtered
+}
+
+// parseDateRange parse inclusive dates as midnight in loc
+func parseDateRange(loc *time.Location, startDate, endDate string) (time.Time, time.Time, error) {
+	start, err := time.ParseInLocation(DateLayout, startDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	end, err := time.ParseInLocation(DateLayout, endDate, loc)
+	if err != nil {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	if end.Before(start) || end.After(start.AddDate(0, 0, maxDateRangeDays)) {
+		return time.Time{}, time.Time{}, ErrInvalidDateRange
+	}
+	return start, end, nil
+}
@@ -0,0 +1,173 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/hex"
+	"errors"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/server"
+	"gorm.io/gorm"
+)
+
+const (
+	// apiKeyPrefix mark the key so leaked keys are easy to spot, e.g. in source code
+	apiKeyPrefix = "bm"
+	// maxAPIKeysPerUser limit active keys of a user
+	maxAPIKeysPerUser = 20
+	// apiKeyTouchInterval limit how often last used time of a key is written
+	apiKeyTouchInterval = time.Minute
+)
+
+// CreateAPIKey issue new API key with scopes taken from the permissions of the
+// user, the returned plain key is only shown once
+func (s *service) CreateAPIKey(ctx context.Context, userID int, req CreateAPIKey) (APIKey, string, error) {
+	req.Name = strings.TrimSpace(req.Name)
+	if req.Name == "" || utf8.RuneCountInString(req.Name) > 100 {
+		return APIKey{}, "", ErrInvalidAPIKeyName
+	}
+	now := time.Now()
+	if req.ExpiresAt != nil && !req.ExpiresAt.After(now) {
+		return APIKey{}, "", ErrInvalidAPIKeyExpiry
+	}
+
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return APIKey{}, "", err
+	}
+	scopes := uniqueStrings(req.Scopes)
+	granted := user.Role.Permissions(user.EmailVerifiedAt != nil)
+	if len(scopes) == 0 || len(restrictPermissions(granted, scopes)) != len(scopes) {
+		return APIKey{}, "", ErrInvalidAPIKeyScope
+	}
+
+	count, err := s.repo.CountActiveAPIKeys(ctx, userID, now)
+	if err != nil {
+		return APIKey{}, "", internalError(err)
+	}
+	if count >= maxAPIKeysPerUser {
+		return APIKey{}, "", ErrTooManyAPIKeys
+	}
+
+	prefix, secret, err := newAPIKey()
+	if err != nil {
+		return APIKey{}, "", internalError(err)
+	}
+	plain := apiKeyPrefix + "_" + prefix + "_" + secret
+	key := APIKey{
+		UserID:    userID,
+		Name:      req.Name,
+		Prefix:    prefix,
+		KeyHash:   hashToken(plain),
+		Scopes:    strings.Join(scopes, " "),
+		ExpiresAt: req.ExpiresAt,
+		CreatedAt: now,
+	}
+	if err := s.repo.CreateAPIKey(ctx, &key); err != nil {
+		return APIKey{}, "", internalError(err)
+	}
+	return key, plain, nil
+}
+
+// ListAPIKeys return the keys of the user which are not revoked
+func (s *service) ListAPIKeys(ctx context.Context, userID int) ([]APIKey, error) {
+	keys, err := s.repo.ListAPIKeys(ctx, userID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return keys, nil
+}
+
+// RevokeAPIKey disable the key immediately
+func (s *service) RevokeAPIKey(ctx context.Context, userID, id int) error {
+	found, err := s.repo.RevokeAPIKey(ctx, userID, id, time.Now())
+	if err != nil {
+		return internalError(err)
+	}
+	if !found {
+		return ErrAPIKeyNotFound
+	}
+	return nil
+}
+
+// AuthenticateAPIKey verify the key and grant the permissions in its scopes
+// which are still granted to the owner
+func (s *service) AuthenticateAPIKey(ctx context.Context, plain string) (server.AuthInfo, error) {
+	parts := strings.SplitN(plain, "_", 3)
+	if len(parts) != 3 || parts[0] != apiKeyPrefix || parts[1] == "" || parts[2] == "" {
+		return server.AuthInfo{}, ErrInvalidAPIKey
+	}
+
+	key, err := s.repo.GetAPIKeyByPrefix(ctx, parts[1])
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return server.AuthInfo{}, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return server.AuthInfo{}, internalError(err)
+	}
+	now := time.Now()
+	if subtle.ConstantTimeCompare([]byte(key.KeyHash), []byte(hashToken(plain))) != 1 ||
+		key.RevokedAt != nil || (key.ExpiresAt != nil && !key.ExpiresAt.After(now)) {
+		return server.AuthInfo{}, ErrInvalidAPIKey
+	}
+
+	user, err := s.repo.GetUserByID(ctx, key.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return server.AuthInfo{}, ErrInvalidAPIKey
+	}
+	if err != nil {
+		return server.AuthInfo{}, internalError(err)
+	}
+
+	s.touchAPIKey(ctx, key.ID, now)
+	return server.AuthInfo{
+		UserID:      user.ID,
+		APIKeyID:    key.ID,
+		Role:        string(user.Role),
+		Permissions: restrictPermissions(user.Role.Permissions(user.EmailVerifiedAt != nil), strings.Fields(key.Scopes)),
+	}, nil
+}
+
+// touchAPIKey update last used time of the key, failure is only logged
+func (s *service) touchAPIKey(ctx context.Context, id int, at time.Time) {
+	touch, err := s.repo.ShouldTouchAPIKey(ctx, id, apiKeyTouchInterval)
+	if err == nil && touch {
+		err = s.repo.TouchAPIKey(ctx, id, at)
+	}
+	if err != nil {
+		logger.Errorf("[user] failed to update api key %d, %v", id, err)
+	}
+}
+
+// newAPIKey generate the public prefix and the secret part of new key
+func newAPIKey() (string, string, error) {
+	b := make([]byte, 6)
+	if _, err := rand.Read(b); err != nil {
+		return "", "", err
+	}
+	secret, err := newOpaqueToken()
+	if err != nil {
+		return "", "", err
+	}
+	return hex.EncodeToString(b), secret, nil
+}
+
+// uniqueStrings trim and drop empty or repeated values, keeping the order
+func uniqueStrings(values []string) []string {
+	seen := make(map[string]bool, len(values))
+	var unique []string
+	for _, v := range values {
+		v = strings.TrimSpace(v)
+		if v == "" || seen[v] {
+			continue
+		}
+		seen[v] = true
+		unique = append(unique, v)
+	}
+	return unique
+}
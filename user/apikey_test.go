@@ -0,0 +1,98 @@
+package user
+
+import (
+	"context"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestCreateAPIKeyScopes(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	verifiedAt := time.Now()
+	staff := repo.addUser(User{Email: "staff@example.com", Role: RoleVenueStaff, EmailVerifiedAt: &verifiedAt})
+	unverified := repo.addUser(User{Email: "new@example.com", Role: RoleVenueStaff})
+
+	tests := []struct {
+		name   string
+		userID int
+		scopes []string
+		want   error
+	}{
+		{"granted scopes", staff.ID, []string{PermissionBookingManage, PermissionBookingCreate}, nil},
+		{"duplicated scopes", staff.ID, []string{PermissionBookingManage, " booking:manage "}, nil},
+		{"no scope", staff.ID, nil, ErrInvalidAPIKeyScope},
+		{"scope not granted to the role", staff.ID, []string{PermissionBookingManage, PermissionVenueManage}, ErrInvalidAPIKeyScope},
+		{"unknown scope", staff.ID, []string{"booking:*"}, ErrInvalidAPIKeyScope},
+		{"scope needing verified email", unverified.ID, []string{PermissionBookingCreate}, ErrInvalidAPIKeyScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, _, err := s.CreateAPIKey(ctx, tt.userID, CreateAPIKey{Name: "ci", Scopes: tt.scopes})
+			if err != tt.want {
+				t.Errorf("CreateAPIKey() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
+
+func TestAuthenticateAPIKeyScopes(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	verifiedAt := time.Now()
+	admin := repo.addUser(User{Email: "admin@example.com", Role: RoleVenueAdmin, EmailVerifiedAt: &verifiedAt})
+
+	key, plain, err := s.CreateAPIKey(ctx, admin.ID, CreateAPIKey{
+		Name:   "sync",
+		Scopes: []string{PermissionBookingManage, PermissionVenueManage},
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if strings.Contains(key.KeyHash, plain) || key.KeyHash != hashToken(plain) {
+		t.Fatal("only the hash of the key should be stored")
+	}
+
+	authInfo, err := s.AuthenticateAPIKey(ctx, plain)
+	if err != nil {
+		t.Fatalf("AuthenticateAPIKey() error = %v", err)
+	}
+	want := []string{PermissionBookingManage, PermissionVenueManage}
+	if authInfo.UserID != admin.ID || authInfo.APIKeyID != key.ID || !reflect.DeepEqual(authInfo.Permissions, want) {
+		t.Errorf("AuthenticateAPIKey() = %+v, want permissions %v", authInfo, want)
+	}
+	if authInfo.HasPermission(PermissionBookingCreate) {
+		t.Error("key must not grant a permission outside its scopes")
+	}
+
+	// the scopes never grant more than the current role of the owner
+	if err := repo.UpdateUser(ctx, admin.ID, map[string]interface{}{"role": RoleVenueStaff}); err != nil {
+		t.Fatal(err)
+	}
+	authInfo, err = s.AuthenticateAPIKey(ctx, plain)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{PermissionBookingManage}; !reflect.DeepEqual(authInfo.Permissions, want) {
+		t.Errorf("permissions after downgrade = %v, want %v", authInfo.Permissions, want)
+	}
+
+	for name, tampered := range map[string]string{
+		"wrong secret": plain[:len(plain)-1] + "x",
+		"wrong prefix": "xx" + plain[2:],
+		"missing part": apiKeyPrefix + "_" + key.Prefix,
+	} {
+		if _, err := s.AuthenticateAPIKey(ctx, tampered); err != ErrInvalidAPIKey {
+			t.Errorf("AuthenticateAPIKey(%s) error = %v, want %v", name, err, ErrInvalidAPIKey)
+		}
+	}
+
+	if err := s.RevokeAPIKey(ctx, admin.ID, key.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.AuthenticateAPIKey(ctx, plain); err != ErrInvalidAPIKey {
+		t.Errorf("AuthenticateAPIKey(revoked) error = %v, want %v", err, ErrInvalidAPIKey)
+	}
+}
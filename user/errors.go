@@ -0,0 +1,54 @@
+package user
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidEmail             = status.Error(codes.InvalidArgument, "email is not a valid email address")
+	ErrInvalidUsername          = status.Error(codes.InvalidArgument, "username must be 3-30 characters of letters, digits, '.' or '_'")
+	ErrInvalidPassword          = status.Error(codes.InvalidArgument, "password must be between 8 and 72 characters")
+	ErrEmailAlreadyExists       = status.Error(codes.AlreadyExists, "email is already registered")
+	ErrUsernameTaken            = status.Error(codes.AlreadyExists, "username is already taken")
+	ErrInvalidCredentials       = status.Error(codes.Unauthenticated, "invalid login or password")
+	ErrInvalidRefreshToken      = status.Error(codes.Unauthenticated, "refresh token is invalid or expired")
+	ErrRefreshTokenReused       = status.Error(codes.Unauthenticated, "refresh token has already been used, please login again")
+	ErrInvalidToken             = status.Error(codes.Unauthenticated, "token is invalid or expired")
+	ErrTokenRevoked             = status.Error(codes.Unauthenticated, "token has been revoked")
+	ErrInvalidRole              = status.Error(codes.InvalidArgument, "role must be one of customer, venue_staff, venue_admin or platform_admin")
+	ErrUserNotFound             = status.Error(codes.NotFound, "user not found")
+	ErrInvalidVerificationToken = status.Error(codes.InvalidArgument, "email verification token is invalid or expired")
+	ErrEmailAlreadyVerified     = status.Error(codes.FailedPrecondition, "email is already verified")
+	ErrInvalidResetCode         = status.Error(codes.InvalidArgument, "password reset code is invalid or expired")
+	ErrInvalidFirebaseToken     = status.Error(codes.Unauthenticated, "firebase ID token is invalid or expired")
+	ErrFirebaseEmailRequired    = status.Error(codes.FailedPrecondition, "firebase account must have an email address")
+	ErrFirebaseEmailUnlinked    = status.Error(codes.AlreadyExists, "email is already registered, login with password to use it")
+	ErrInvalidDisplayName       = status.Error(codes.InvalidArgument, "display name must be at most 100 characters")
+	ErrInvalidPhone             = status.Error(codes.InvalidArgument, "phone must be 6-20 digits, optionally prefixed with +")
+	ErrInvalidAvatarURL         = status.Error(codes.InvalidArgument, "avatar url must be an absolute http or https url")
+	ErrInvalidLocale            = status.Error(codes.InvalidArgument, "locale must be a language tag such as en or en-US")
+	ErrInvalidTimezone          = status.Error(codes.InvalidArgument, "timezone must be an IANA time zone such as Asia/Jakarta")
+	ErrWrongPassword            = status.Error(codes.PermissionDenied, "current password is wrong")
+	ErrTOTPAlreadyEnabled       = status.Error(codes.FailedPrecondition, "two factor authentication is already enabled")
+	ErrTOTPNotEnrolled          = status.Error(codes.FailedPrecondition, "call EnrollTOTP before confirming two factor authentication")
+	ErrTOTPNotEnabled           = status.Error(codes.FailedPrecondition, "two factor authentication is not enabled")
+	ErrInvalidTOTPCode          = status.Error(codes.InvalidArgument, "authentication code is invalid")
+	ErrInvalidPreAuth           = status.Error(codes.Unauthenticated, "pre-auth token is invalid or expired, please login again")
+	ErrSessionNotFound          = status.Error(codes.NotFound, "session not found")
+	ErrInvalidAPIKeyName        = status.Error(codes.InvalidArgument, "api key name must be 1-100 characters")
+	ErrInvalidAPIKeyScope       = status.Error(codes.InvalidArgument, "api key scopes must be permissions granted to you")
+	ErrInvalidAPIKeyExpiry      = status.Error(codes.InvalidArgument, "api key expiry must be in the future")
+	ErrTooManyAPIKeys           = status.Error(codes.ResourceExhausted, "too many api keys, revoke an unused one first")
+	ErrAPIKeyNotFound           = status.Error(codes.NotFound, "api key not found")
+	ErrInvalidAPIKey            = status.Error(codes.Unauthenticated, "api key is invalid, expired or revoked")
+	ErrAPIKeyNotAllowed         = status.Error(codes.PermissionDenied, "this call requires a login token instead of an api key")
+	ErrInternal                 = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[user] %v", err)
+	return ErrInternal
+}
@@ -0,0 +1,111 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/golang/protobuf/ptypes"
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// loginGuard hold the brute force protection setting of Login
+type loginGuard struct {
+	maxFailures      int
+	maxFailuresPerIP int
+	window           time.Duration
+	lockoutBase      time.Duration
+	lockoutMax       time.Duration
+}
+
+// loginSubjects return the counter keys of the account and the client IP,
+// the login is hashed so unknown login is counted the same as existing one.
+// client is empty when the IP is unknown, otherwise every such caller would
+// share one counter and could be locked out together
+func loginSubjects(login, ip string) (account, client string) {
+	account = "account:" + hashToken(login)
+	if ip != "" {
+		client = "ip:" + ip
+	}
+	return account, client
+}
+
+// checkLoginLock reject the attempt while the account or IP is locked,
+// empty subject is skipped
+func (s *service) checkLoginLock(ctx context.Context, subjects ...string) error {
+	var keys []string
+	for _, subject := range subjects {
+		if subject != "" {
+			keys = append(keys, subject)
+		}
+	}
+	remaining, err := s.repo.GetLoginLock(ctx, keys...)
+	if err != nil {
+		return internalError(err)
+	}
+	if remaining > 0 {
+		return errTooManyLoginAttempts(remaining)
+	}
+	return nil
+}
+
+// recordLoginFailure count the failure and lock the account or IP once the
+// threshold is reached, every further failure double the lockout duration.
+// Empty client is not counted
+func (s *service) recordLoginFailure(ctx context.Context, account, client string) error {
+	var lockout time.Duration
+	for _, subject := range []struct {
+		key         string
+		maxFailures int
+	}{
+		{account, s.loginGuard.maxFailures},
+		{client, s.loginGuard.maxFailuresPerIP},
+	} {
+		if subject.key == "" {
+			continue
+		}
+		failures, err := s.repo.IncrLoginFailures(ctx, subject.key, s.loginGuard.window)
+		if err != nil {
+			return internalError(err)
+		}
+		if failures < subject.maxFailures {
+			continue
+		}
+
+		duration := s.loginGuard.lockoutDuration(failures - subject.maxFailures)
+		if err := s.repo.LockLogin(ctx, subject.key, duration); err != nil {
+			return internalError(err)
+		}
+		if duration > lockout {
+			lockout = duration
+		}
+	}
+
+	if lockout > 0 {
+		return errTooManyLoginAttempts(lockout)
+	}
+	return ErrInvalidCredentials
+}
+
+// lockoutDuration is lockoutBase * 2^excess capped at lockoutMax
+func (g loginGuard) lockoutDuration(excess int) time.Duration {
+	duration := float64(g.lockoutBase) * math.Pow(2, float64(excess))
+	if duration > float64(g.lockoutMax) {
+		return g.lockoutMax
+	}
+	return time.Duration(duration)
+}
+
+// errTooManyLoginAttempts build ResourceExhausted status with RetryInfo detail
+// which is exposed as Retry-After header by the server
+func errTooManyLoginAttempts(retryAfter time.Duration) error {
+	st := status.New(codes.ResourceExhausted, fmt.Sprintf("too many failed login attempts, retry after %d seconds", int64(math.Ceil(retryAfter.Seconds()))))
+	detailed, err := st.WithDetails(&errdetails.RetryInfo{RetryDelay: ptypes.DurationProto(retryAfter)})
+	if err != nil {
+		return st.Err()
+	}
+	return detailed.Err()
+}
@@ -0,0 +1,42 @@
+package user
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/booking-man-be/lib/server"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+func TestLoginLockoutPerIP(t *testing.T) {
+	ctx := context.Background()
+	s, _ := newTestService(t)
+	attack := func(ip string) {
+		t.Helper()
+		for i := 0; i < s.loginGuard.maxFailuresPerIP; i++ {
+			clientInfo := server.ClientInfo{ClientID: "web", IP: ip}
+			s.Login(ctx, fmt.Sprintf("user%d@example.com", i), "wrong password", clientInfo)
+		}
+	}
+	locked := func(ip string) bool {
+		t.Helper()
+		_, err := s.Login(ctx, "someone@example.com", "wrong password", server.ClientInfo{ClientID: "web", IP: ip})
+		return status.Code(err) == codes.ResourceExhausted
+	}
+
+	attack("203.0.113.7")
+	if !locked("203.0.113.7") {
+		t.Error("IP should be locked after too many failures")
+	}
+	if locked("203.0.113.8") {
+		t.Error("another IP should not be locked")
+	}
+
+	// callers whose IP is unknown do not share a counter
+	attack("")
+	if locked("") {
+		t.Error("unknown IP should not be locked")
+	}
+}
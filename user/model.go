@@ -1,13 +1,115 @@
 package user
 
+import (
+	"time"
+
+	"gorm.io/gorm"
+)
+
 type LoginToken struct {
 	RefreshToken   string
 	Token          string
 	TokenExpiresIn int64
 	FirebaseToken  string
 	TokenID        string
+	// MFARequired is set instead of the tokens when the user enabled TOTP,
+	// PreAuthToken must then be exchanged through LoginTOTP
+	MFARequired  bool
+	PreAuthToken string
 }
 
 type User struct {
-	ID int `gorm:"primary_key"`
+	ID           int    `gorm:"primary_key"`
+	Email        string `gorm:"type:varchar(255);not null;uniqueIndex"`
+	Username     string `gorm:"type:varchar(50);not null;uniqueIndex"`
+	PasswordHash string `gorm:"type:varchar(255);not null"`
+	Role         Role   `gorm:"type:varchar(20);not null;default:customer"`
+	// EmailVerifiedAt is nil until the user verify the email
+	EmailVerifiedAt *time.Time
+	// FirebaseUID link the user to Firebase account
+	FirebaseUID *string `gorm:"type:varchar(128);uniqueIndex"`
+
+	// two factor authentication
+	TOTPSecret string `gorm:"type:varchar(64);not null;default:''"`
+	// TOTPEnabledAt is nil until the enrolled secret is confirmed
+	TOTPEnabledAt *time.Time
+	// RecoveryCodes is JSON array of hashed one time recovery codes
+	RecoveryCodes string `gorm:"type:text"`
+
+	// profile
+	DisplayName string `gorm:"type:varchar(100);not null;default:''"`
+	Phone       string `gorm:"type:varchar(30);not null;default:''"`
+	AvatarURL   string `gorm:"type:varchar(512);not null;default:''"`
+	Locale      string `gorm:"type:varchar(20);not null;default:''"`
+	Timezone    string `gorm:"type:varchar(64);not null;default:''"`
+
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+	// DeletedAt soft delete the account, personal data is anonymised after grace period
+	DeletedAt    gorm.DeletedAt `gorm:"index"`
+	AnonymizedAt *time.Time
+}
+
+// Session is a login of the user on a device, its ID is the token family ID
+// shared by every access and refresh token issued since the login
+type Session struct {
+	ID         string    `gorm:"type:char(36);primary_key"`
+	UserID     int       `gorm:"not null;index"`
+	DeviceID   string    `gorm:"type:varchar(128);not null;default:''"`
+	ClientID   string    `gorm:"type:varchar(64);not null;default:''"`
+	UserAgent  string    `gorm:"type:varchar(255);not null;default:''"`
+	IP         string    `gorm:"type:varchar(45);not null;default:''"`
+	LastSeenAt time.Time `gorm:"not null"`
+	// ExpiresAt is when the latest refresh token of the session expires
+	ExpiresAt time.Time `gorm:"not null"`
+	CreatedAt time.Time `gorm:"not null"`
+	RevokedAt *time.Time
+}
+
+// APIKey is long lived credential for server to server integration, only the
+// prefix and the hash of the key are stored
+type APIKey struct {
+	ID     int    `gorm:"primary_key"`
+	UserID int    `gorm:"not null;index"`
+	Name   string `gorm:"type:varchar(100);not null"`
+	// Prefix is the public part of the key used to look it up
+	Prefix  string `gorm:"type:varchar(16);not null;uniqueIndex"`
+	KeyHash string `gorm:"type:char(64);not null"`
+	// Scopes is space separated permissions granted to the key
+	Scopes string `gorm:"type:varchar(512);not null"`
+	// ExpiresAt is nil for key which never expires
+	ExpiresAt  *time.Time
+	LastUsedAt *time.Time
+	CreatedAt  time.Time `gorm:"not null"`
+	RevokedAt  *time.Time
+}
+
+// CreateAPIKey is the input needed to issue a new API key
+type CreateAPIKey struct {
+	Name   string
+	Scopes []string
+	// ExpiresAt is optional
+	ExpiresAt *time.Time
+}
+
+// UpdateProfile is partial update of the user profile, nil field is left untouched
+type UpdateProfile struct {
+	DisplayName *string
+	Phone       *string
+	AvatarURL   *string
+	Locale      *string
+	Timezone    *string
+}
+
+// RegisterUser is the input needed to create a new account
+type RegisterUser struct {
+	Email    string
+	Username string
+	Password string
+}
+
+// TOTPEnrollment is the secret to be added into authenticator app
+type TOTPEnrollment struct {
+	Secret string
+	URI    string
 }
@@ -0,0 +1,168 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"net/url"
+	"regexp"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/logger"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	maxDisplayNameLength = 100
+	// anonymizeBatchSize is number of deleted users anonymised per query
+	anonymizeBatchSize = 100
+)
+
+var (
+	phoneRegex  = regexp.MustCompile(`^\+?[0-9]{6,20}$`)
+	localeRegex = regexp.MustCompile(`^[a-zA-Z]{2,3}(-[a-zA-Z0-9]{2,8})*$`)
+)
+
+// GetUser return the active user
+func (s *service) GetUser(ctx context.Context, userID int) (User, error) {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return User{}, ErrUserNotFound
+	}
+	if err != nil {
+		return User{}, internalError(err)
+	}
+	return user, nil
+}
+
+// UpdateProfile validate and save the given profile fields
+func (s *service) UpdateProfile(ctx context.Context, userID int, req UpdateProfile) (User, error) {
+	fields := map[string]interface{}{}
+	if req.DisplayName != nil {
+		displayName := strings.TrimSpace(*req.DisplayName)
+		if utf8.RuneCountInString(displayName) > maxDisplayNameLength {
+			return User{}, ErrInvalidDisplayName
+		}
+		fields["display_name"] = displayName
+	}
+	if req.Phone != nil {
+		phone := strings.NewReplacer(" ", "", "-", "").Replace(*req.Phone)
+		if phone != "" && !phoneRegex.MatchString(phone) {
+			return User{}, ErrInvalidPhone
+		}
+		fields["phone"] = phone
+	}
+	if req.AvatarURL != nil {
+		avatarURL := strings.TrimSpace(*req.AvatarURL)
+		if avatarURL != "" && !isHTTPURL(avatarURL) {
+			return User{}, ErrInvalidAvatarURL
+		}
+		fields["avatar_url"] = avatarURL
+	}
+	if req.Locale != nil {
+		if *req.Locale != "" && !localeRegex.MatchString(*req.Locale) {
+			return User{}, ErrInvalidLocale
+		}
+		fields["locale"] = *req.Locale
+	}
+	if req.Timezone != nil {
+		if _, err := time.LoadLocation(*req.Timezone); err != nil || *req.Timezone == "Local" {
+			return User{}, ErrInvalidTimezone
+		}
+		fields["timezone"] = *req.Timezone
+	}
+
+	if len(fields) > 0 {
+		if err := s.repo.UpdateUser(ctx, userID, fields); err != nil {
+			return User{}, internalError(err)
+		}
+	}
+	return s.GetUser(ctx, userID)
+}
+
+// ChangePassword replace the password after checking the current one,
+// every other session of the user is revoked
+func (s *service) ChangePassword(ctx context.Context, userID int, sessionID, currentPassword, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(currentPassword)) != nil {
+		return ErrWrongPassword
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return internalError(err)
+	}
+	if err := s.repo.UpdatePassword(ctx, userID, hash); err != nil {
+		return internalError(err)
+	}
+
+	familyIDs, err := s.repo.ListTokenFamilies(ctx, userID)
+	if err != nil {
+		return internalError(err)
+	}
+	for _, familyID := range familyIDs {
+		if familyID == sessionID {
+			continue
+		}
+		if err := s.revokeSession(ctx, familyID); err != nil {
+			return internalError(err)
+		}
+	}
+	return nil
+}
+
+// DeleteAccount soft delete the user and revoke every session, password is
+// required unless the account only login through Firebase
+func (s *service) DeleteAccount(ctx context.Context, userID int, password string) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.PasswordHash != "" && bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return ErrWrongPassword
+	}
+
+	if err := s.repo.DeleteUser(ctx, userID); err != nil {
+		return internalError(err)
+	}
+	return s.LogoutAll(ctx, userID)
+}
+
+// AnonymizeDeletedUsers erase personal data of users deleted longer than
+// the grace period, it return number of anonymised users
+func (s *service) AnonymizeDeletedUsers(ctx context.Context) (int, error) {
+	deletedBefore := time.Now().Add(-s.accountDeletionGracePeriod)
+	count := 0
+	for {
+		users, err := s.repo.ListUsersToAnonymize(ctx, deletedBefore, anonymizeBatchSize)
+		if err != nil {
+			return count, err
+		}
+		for _, user := range users {
+			if err := s.repo.AnonymizeUser(ctx, user.ID, time.Now()); err != nil {
+				return count, err
+			}
+			count++
+		}
+		if len(users) < anonymizeBatchSize {
+			break
+		}
+	}
+	if count > 0 {
+		logger.Infof("[user] anonymised %d deleted users", count)
+	}
+	return count, nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
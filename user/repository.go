@@ -1,16 +1,142 @@
 package user
 
 import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-sql-driver/mysql"
 	"github.com/gomodule/redigo/redis"
 	"gorm.io/gorm"
 )
 
+// mysqlErrDuplicateEntry is returned by mysql when a unique index is violated
+const mysqlErrDuplicateEntry = 1062
+
+// redis keys
+const (
+	// keyToken hold the user ID of an issued access token
+	keyToken = "user:token:%s"
+	// keyUserTokens is set of token IDs issued to the user
+	keyUserTokens = "user:tokens:%d"
+	// keyRefreshToken hold RefreshToken record by hashed refresh token
+	keyRefreshToken = "user:refresh_token:%s"
+	// keyRotatedRefreshToken hold RefreshToken record of already rotated refresh token
+	keyRotatedRefreshToken = "user:rotated_refresh_token:%s"
+	// keyUserFamilies is set of token family IDs of the user
+	keyUserFamilies = "user:families:%d"
+	// keyFamilyTokens is set of token IDs issued in the token family
+	keyFamilyTokens = "user:family_tokens:%s"
+	// keyRevokedFamily mark revoked token family
+	keyRevokedFamily = "user:revoked_family:%s"
+	// keyRevokedToken is the denylist entry of revoked access token,
+	// it expires together with the token
+	keyRevokedToken = "user:revoked_token:%s"
+	// keyEmailVerification hold the user ID by hashed email verification token
+	keyEmailVerification = "user:email_verification:%s"
+	// keyPasswordReset hold hashed password reset code of the user
+	keyPasswordReset = "user:password_reset:%d"
+	// keyPasswordResetAttempts count wrong password reset code of the user
+	keyPasswordResetAttempts = "user:password_reset_attempts:%d"
+	// keyLoginFailures count failed login of an account or IP
+	keyLoginFailures = "user:login_failures:%s"
+	// keyLoginLock block login of an account or IP until it expires
+	keyLoginLock = "user:login_lock:%s"
+	// keyPreAuth hold PreAuth record by hashed pre-auth token waiting for TOTP code
+	keyPreAuth = "user:pre_auth:%s"
+	// keyPreAuthAttempts count wrong TOTP code of the pre-auth token
+	keyPreAuthAttempts = "user:pre_auth_attempts:%s"
+	// keyTOTPUsed mark TOTP code of the user as used so it can not be replayed
+	keyTOTPUsed = "user:totp_used:%d:%s"
+	// keySessionSeen throttle update of session last seen time
+	keySessionSeen = "user:session_seen:%s"
+	// keyAPIKeySeen throttle update of API key last used time
+	keyAPIKeySeen = "user:api_key_seen:%d"
+)
+
+// extendExpire set the expiry of KEYS[1] to ARGV[1] seconds unless it already
+// expires later, so a short lived login never shorten a set shared with long lived ones
+var extendExpire = redis.NewScript(1, `
+local ttl = redis.call('TTL', KEYS[1])
+if ttl < tonumber(ARGV[1]) then
+	return redis.call('EXPIRE', KEYS[1], ARGV[1])
+end
+return 0
+`)
+
 type repository struct {
 	db        *gorm.DB
 	redisPool *redis.Pool
 }
 
 type Repository interface {
+	CreateUser(ctx context.Context, user *User) error
+	IsEmailExist(ctx context.Context, email string) (bool, error)
+	IsUsernameExist(ctx context.Context, username string) (bool, error)
+	GetUserByLogin(ctx context.Context, login string) (User, error)
+	GetUserByID(ctx context.Context, id int) (User, error)
+	GetUserByEmail(ctx context.Context, email string) (User, error)
+	GetUserByFirebaseUID(ctx context.Context, uid string) (User, error)
+	LinkFirebaseUID(ctx context.Context, id int, uid string) error
+	UpdatePassword(ctx context.Context, id int, passwordHash string) error
+	UpdateUser(ctx context.Context, id int, fields map[string]interface{}) error
+	DeleteUser(ctx context.Context, id int) error
+	ListUsersToAnonymize(ctx context.Context, deletedBefore time.Time, limit int) ([]User, error)
+	AnonymizeUser(ctx context.Context, id int, at time.Time) error
+	ReplaceRecoveryCodes(ctx context.Context, id int, old, new string) (bool, error)
+	UpdateUserRole(ctx context.Context, id int, role Role) (bool, error)
+	MarkEmailVerified(ctx context.Context, id int, at time.Time) error
+
+	CreateSession(ctx context.Context, session *Session) error
+	GetSession(ctx context.Context, id string) (Session, error)
+	ListActiveSessions(ctx context.Context, userID int, now time.Time) ([]Session, error)
+	ListDeviceSessions(ctx context.Context, userID int, deviceID string) ([]Session, error)
+	TouchSession(ctx context.Context, id, ip string, at time.Time) error
+	ExtendSession(ctx context.Context, id string, expiresAt time.Time) error
+	MarkSessionRevoked(ctx context.Context, id string, at time.Time) error
+	MarkUserSessionsRevoked(ctx context.Context, userID int, at time.Time) error
+
+	CreateAPIKey(ctx context.Context, key *APIKey) error
+	CountActiveAPIKeys(ctx context.Context, userID int, now time.Time) (int64, error)
+	ListAPIKeys(ctx context.Context, userID int) ([]APIKey, error)
+	GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id int, at time.Time) (bool, error)
+	TouchAPIKey(ctx context.Context, id int, at time.Time) error
+
+	StoreToken(ctx context.Context, userID int, familyID, tokenID string, tokenTTL, refreshTTL time.Duration) error
+	StoreRefreshToken(ctx context.Context, tokenHash string, token RefreshToken, ttl time.Duration) error
+	ConsumeRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error)
+	StoreRotatedRefreshToken(ctx context.Context, tokenHash string, token RefreshToken, ttl time.Duration) error
+	GetRotatedRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error)
+	RevokeTokenFamily(ctx context.Context, familyID string, ttl time.Duration) error
+	IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error)
+	RevokeUserTokens(ctx context.Context, userID int, ttl time.Duration) error
+	ListTokenFamilies(ctx context.Context, userID int) ([]string, error)
+	IsTokenRevoked(ctx context.Context, tokenID string) (bool, error)
+
+	StoreEmailVerificationToken(ctx context.Context, tokenHash string, userID int, ttl time.Duration) error
+	ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (int, error)
+
+	StorePasswordResetCode(ctx context.Context, userID int, codeHash string, ttl time.Duration) error
+	GetPasswordResetCode(ctx context.Context, userID int) (string, error)
+	IncrPasswordResetAttempts(ctx context.Context, userID int) (int, error)
+	DeletePasswordResetCode(ctx context.Context, userID int) (bool, error)
+
+	GetLoginLock(ctx context.Context, subjects ...string) (time.Duration, error)
+	IncrLoginFailures(ctx context.Context, subject string, window time.Duration) (int, error)
+	LockLogin(ctx context.Context, subject string, duration time.Duration) error
+	ResetLoginFailures(ctx context.Context, subject string) error
+
+	StorePreAuthToken(ctx context.Context, tokenHash string, preAuth PreAuth, ttl time.Duration) error
+	GetPreAuthToken(ctx context.Context, tokenHash string) (PreAuth, error)
+	IncrPreAuthAttempts(ctx context.Context, tokenHash string, ttl time.Duration) (int, error)
+	DeletePreAuthToken(ctx context.Context, tokenHash string) (bool, error)
+	MarkTOTPCodeUsed(ctx context.Context, userID int, code string, ttl time.Duration) (bool, error)
+	ShouldTouchSession(ctx context.Context, id string, interval time.Duration) (bool, error)
+	ShouldTouchAPIKey(ctx context.Context, id int, interval time.Duration) (bool, error)
 }
 
 func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
@@ -19,3 +145,703 @@ func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
 		redisPool: redis,
 	}
 }
+
+// CreateUser insert new user, duplicate email or username
+// is reported as ErrEmailAlreadyExists or ErrUsernameTaken
+func (r *repository) CreateUser(ctx context.Context, user *User) error {
+	err := r.db.WithContext(ctx).Create(user).Error
+	var mysqlErr *mysql.MySQLError
+	if errors.As(err, &mysqlErr) && mysqlErr.Number == mysqlErrDuplicateEntry {
+		if strings.Contains(mysqlErr.Message, "email") {
+			return ErrEmailAlreadyExists
+		}
+		return ErrUsernameTaken
+	}
+	return err
+}
+
+func (r *repository) IsEmailExist(ctx context.Context, email string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&User{}).Where("email = ?", email).Count(&count).Error
+	return count > 0, err
+}
+
+func (r *repository) IsUsernameExist(ctx context.Context, username string) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&User{}).Where("username = ?", username).Count(&count).Error
+	return count > 0, err
+}
+
+// GetUserByLogin find user by email or username, return gorm.ErrRecordNotFound if no match
+func (r *repository) GetUserByLogin(ctx context.Context, login string) (User, error) {
+	var user User
+	err := r.db.WithContext(ctx).Where("email = ? OR username = ?", login, login).First(&user).Error
+	return user, err
+}
+
+// GetUserByID return gorm.ErrRecordNotFound if the user does not exist
+func (r *repository) GetUserByID(ctx context.Context, id int) (User, error) {
+	var user User
+	err := r.db.WithContext(ctx).First(&user, id).Error
+	return user, err
+}
+
+// GetUserByEmail return gorm.ErrRecordNotFound if the user does not exist
+func (r *repository) GetUserByEmail(ctx context.Context, email string) (User, error) {
+	var user User
+	err := r.db.WithContext(ctx).Where("email = ?", email).First(&user).Error
+	return user, err
+}
+
+// GetUserByFirebaseUID return gorm.ErrRecordNotFound if no user is linked to the Firebase account
+func (r *repository) GetUserByFirebaseUID(ctx context.Context, uid string) (User, error) {
+	var user User
+	err := r.db.WithContext(ctx).Where("firebase_uid = ?", uid).First(&user).Error
+	return user, err
+}
+
+func (r *repository) LinkFirebaseUID(ctx context.Context, id int, uid string) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("firebase_uid", uid).Error
+}
+
+func (r *repository) UpdatePassword(ctx context.Context, id int, passwordHash string) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("password_hash", passwordHash).Error
+}
+
+func (r *repository) UpdateUser(ctx context.Context, id int, fields map[string]interface{}) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Updates(fields).Error
+}
+
+// DeleteUser soft delete the user
+func (r *repository) DeleteUser(ctx context.Context, id int) error {
+	return r.db.WithContext(ctx).Delete(&User{}, id).Error
+}
+
+// ListUsersToAnonymize return soft deleted users whose grace period is over
+func (r *repository) ListUsersToAnonymize(ctx context.Context, deletedBefore time.Time, limit int) ([]User, error) {
+	var users []User
+	err := r.db.WithContext(ctx).Unscoped().
+		Where("deleted_at IS NOT NULL AND deleted_at < ? AND anonymized_at IS NULL", deletedBefore).
+		Limit(limit).
+		Find(&users).Error
+	return users, err
+}
+
+// AnonymizeUser erase personal data of deleted user, email and username
+// are replaced so they can be registered again
+func (r *repository) AnonymizeUser(ctx context.Context, id int, at time.Time) error {
+	return r.db.WithContext(ctx).Unscoped().Model(&User{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"email":         fmt.Sprintf("deleted-%d@deleted.invalid", id),
+		"username":      fmt.Sprintf("deleted_%d", id),
+		"password_hash": "",
+		"firebase_uid":  nil,
+		"display_name":  "",
+		"phone":         "",
+		"avatar_url":    "",
+		"locale":        "",
+		"timezone":      "",
+		"anonymized_at": at,
+	}).Error
+}
+
+// ReplaceRecoveryCodes only update when the stored codes are still old,
+// so the same recovery code can not be used twice concurrently
+func (r *repository) ReplaceRecoveryCodes(ctx context.Context, id int, old, new string) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&User{}).
+		Where("id = ? AND recovery_codes = ?", id, old).
+		Update("recovery_codes", new)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) CreateSession(ctx context.Context, session *Session) error {
+	return r.db.WithContext(ctx).Create(session).Error
+}
+
+// GetSession return gorm.ErrRecordNotFound if the session does not exist
+func (r *repository) GetSession(ctx context.Context, id string) (Session, error) {
+	var session Session
+	err := r.db.WithContext(ctx).Where("id = ?", id).First(&session).Error
+	return session, err
+}
+
+// ListActiveSessions return sessions which are not revoked and not expired at now, most recent first
+func (r *repository) ListActiveSessions(ctx context.Context, userID int, now time.Time) ([]Session, error) {
+	var sessions []Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL AND expires_at > ?", userID, now).
+		Order("last_seen_at DESC").
+		Find(&sessions).Error
+	return sessions, err
+}
+
+// ListDeviceSessions return sessions of the device which are not revoked yet
+func (r *repository) ListDeviceSessions(ctx context.Context, userID int, deviceID string) ([]Session, error) {
+	var sessions []Session
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND device_id = ? AND revoked_at IS NULL", userID, deviceID).
+		Find(&sessions).Error
+	return sessions, err
+}
+
+func (r *repository) TouchSession(ctx context.Context, id, ip string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&Session{}).Where("id = ?", id).Updates(map[string]interface{}{
+		"ip":           ip,
+		"last_seen_at": at,
+	}).Error
+}
+
+func (r *repository) ExtendSession(ctx context.Context, id string, expiresAt time.Time) error {
+	return r.db.WithContext(ctx).Model(&Session{}).Where("id = ?", id).Update("expires_at", expiresAt).Error
+}
+
+func (r *repository) MarkSessionRevoked(ctx context.Context, id string, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&Session{}).
+		Where("id = ? AND revoked_at IS NULL", id).
+		Update("revoked_at", at).Error
+}
+
+func (r *repository) MarkUserSessionsRevoked(ctx context.Context, userID int, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&Session{}).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Update("revoked_at", at).Error
+}
+
+func (r *repository) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	return r.db.WithContext(ctx).Create(key).Error
+}
+
+// CountActiveAPIKeys count keys of the user which are neither revoked nor expired
+func (r *repository) CountActiveAPIKeys(ctx context.Context, userID int, now time.Time) (int64, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&APIKey{}).
+		Where("user_id = ? AND revoked_at IS NULL AND (expires_at IS NULL OR expires_at > ?)", userID, now).
+		Count(&count).Error
+	return count, err
+}
+
+// ListAPIKeys return keys of the user which are not revoked, newest first
+func (r *repository) ListAPIKeys(ctx context.Context, userID int) ([]APIKey, error) {
+	var keys []APIKey
+	err := r.db.WithContext(ctx).
+		Where("user_id = ? AND revoked_at IS NULL", userID).
+		Order("id DESC").
+		Find(&keys).Error
+	return keys, err
+}
+
+// GetAPIKeyByPrefix return gorm.ErrRecordNotFound if there is no key with the prefix
+func (r *repository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	var key APIKey
+	err := r.db.WithContext(ctx).Where("prefix = ?", prefix).First(&key).Error
+	return key, err
+}
+
+// RevokeAPIKey return false if the user has no such key or it is already revoked
+func (r *repository) RevokeAPIKey(ctx context.Context, userID, id int, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&APIKey{}).
+		Where("id = ? AND user_id = ? AND revoked_at IS NULL", id, userID).
+		Update("revoked_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) TouchAPIKey(ctx context.Context, id int, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&APIKey{}).Where("id = ?", id).Update("last_used_at", at).Error
+}
+
+// UpdateUserRole return false if the user does not exist
+func (r *repository) UpdateUserRole(ctx context.Context, id int, role Role) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("role", role)
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) MarkEmailVerified(ctx context.Context, id int, at time.Time) error {
+	return r.db.WithContext(ctx).Model(&User{}).Where("id = ?", id).Update("email_verified_at", at).Error
+}
+
+// StoreToken track issued access token so it can be revoked later. The per family
+// set live as long as the refresh token, the per user sets as long as the longest
+// lived refresh token of the user since clients may give different lifetimes
+func (r *repository) StoreToken(ctx context.Context, userID int, familyID, tokenID string, tokenTTL, refreshTTL time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	refreshTTLSeconds := int64(refreshTTL.Seconds())
+	userTokensKey := fmt.Sprintf(keyUserTokens, userID)
+	userFamiliesKey := fmt.Sprintf(keyUserFamilies, userID)
+	familyTokensKey := fmt.Sprintf(keyFamilyTokens, familyID)
+	conn.Send("MULTI")
+	conn.Send("SET", fmt.Sprintf(keyToken, tokenID), userID, "EX", int64(tokenTTL.Seconds()))
+	conn.Send("SADD", userTokensKey, tokenID)
+	extendExpire.Send(conn, userTokensKey, refreshTTLSeconds)
+	conn.Send("SADD", userFamiliesKey, familyID)
+	extendExpire.Send(conn, userFamiliesKey, refreshTTLSeconds)
+	conn.Send("SADD", familyTokensKey, tokenID)
+	conn.Send("EXPIRE", familyTokensKey, refreshTTLSeconds)
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+func (r *repository) StoreRefreshToken(ctx context.Context, tokenHash string, token RefreshToken, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	value, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("SET", fmt.Sprintf(keyRefreshToken, tokenHash), value, "EX", int64(ttl.Seconds()))
+	return err
+}
+
+// ConsumeRefreshToken atomically get and delete refresh token record so it can
+// only be exchanged once, return redis.ErrNil if the token is unknown
+func (r *repository) ConsumeRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	defer conn.Close()
+
+	key := fmt.Sprintf(keyRefreshToken, tokenHash)
+	conn.Send("MULTI")
+	conn.Send("GET", key)
+	conn.Send("DEL", key)
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	return decodeRefreshToken(values[0], nil)
+}
+
+// StoreRotatedRefreshToken keep record of exchanged refresh token to detect reuse
+func (r *repository) StoreRotatedRefreshToken(ctx context.Context, tokenHash string, token RefreshToken, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	value, err := json.Marshal(token)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("SET", fmt.Sprintf(keyRotatedRefreshToken, tokenHash), value, "EX", int64(ttl.Seconds()))
+	return err
+}
+
+// GetRotatedRefreshToken return redis.ErrNil if the token was never rotated
+func (r *repository) GetRotatedRefreshToken(ctx context.Context, tokenHash string) (RefreshToken, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	defer conn.Close()
+
+	return decodeRefreshToken(conn.Do("GET", fmt.Sprintf(keyRotatedRefreshToken, tokenHash)))
+}
+
+// RevokeTokenFamily mark the family as revoked so its refresh token can not be
+// exchanged anymore and deny every access token issued in the family. The mark
+// live for ttl, or longer if the family was issued a longer lived refresh token
+func (r *repository) RevokeTokenFamily(ctx context.Context, familyID string, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	return revokeTokenFamily(conn, familyID, ttl)
+}
+
+// RevokeUserTokens revoke every token family of the user
+func (r *repository) RevokeUserTokens(ctx context.Context, userID int, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	userFamiliesKey := fmt.Sprintf(keyUserFamilies, userID)
+	familyIDs, err := redis.Strings(conn.Do("SMEMBERS", userFamiliesKey))
+	if err != nil {
+		return err
+	}
+	for _, familyID := range familyIDs {
+		if err := revokeTokenFamily(conn, familyID, ttl); err != nil {
+			return err
+		}
+	}
+
+	_, err = conn.Do("DEL", userFamiliesKey, fmt.Sprintf(keyUserTokens, userID))
+	return err
+}
+
+// ListTokenFamilies return every token family ID of the user
+func (r *repository) ListTokenFamilies(ctx context.Context, userID int) ([]string, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return nil, err
+	}
+	defer conn.Close()
+
+	return redis.Strings(conn.Do("SMEMBERS", fmt.Sprintf(keyUserFamilies, userID)))
+}
+
+func (r *repository) IsTokenRevoked(ctx context.Context, tokenID string) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("EXISTS", fmt.Sprintf(keyRevokedToken, tokenID)))
+}
+
+func (r *repository) IsTokenFamilyRevoked(ctx context.Context, familyID string) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	return redis.Bool(conn.Do("EXISTS", fmt.Sprintf(keyRevokedFamily, familyID)))
+}
+
+func decodeRefreshToken(reply interface{}, err error) (RefreshToken, error) {
+	value, err := redis.Bytes(reply, err)
+	if err != nil {
+		return RefreshToken{}, err
+	}
+	var token RefreshToken
+	err = json.Unmarshal(value, &token)
+	return token, err
+}
+
+func (r *repository) StoreEmailVerificationToken(ctx context.Context, tokenHash string, userID int, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SET", fmt.Sprintf(keyEmailVerification, tokenHash), userID, "EX", int64(ttl.Seconds()))
+	return err
+}
+
+// ConsumeEmailVerificationToken atomically get and delete the token so it can only be used once,
+// return redis.ErrNil if the token is unknown or expired
+func (r *repository) ConsumeEmailVerificationToken(ctx context.Context, tokenHash string) (int, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	key := fmt.Sprintf(keyEmailVerification, tokenHash)
+	conn.Send("MULTI")
+	conn.Send("GET", key)
+	conn.Send("DEL", key)
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, err
+	}
+	return redis.Int(values[0], nil)
+}
+
+// StorePasswordResetCode replace previous code of the user and reset the attempt counter
+func (r *repository) StorePasswordResetCode(ctx context.Context, userID int, codeHash string, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("SET", fmt.Sprintf(keyPasswordReset, userID), codeHash, "EX", int64(ttl.Seconds()))
+	conn.Send("DEL", fmt.Sprintf(keyPasswordResetAttempts, userID))
+	_, err = conn.Do("EXEC")
+	return err
+}
+
+// GetPasswordResetCode return redis.ErrNil if there is no active code
+func (r *repository) GetPasswordResetCode(ctx context.Context, userID int) (string, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return "", err
+	}
+	defer conn.Close()
+
+	return redis.String(conn.Do("GET", fmt.Sprintf(keyPasswordReset, userID)))
+}
+
+// IncrPasswordResetAttempts count wrong code, the counter expire with the code
+func (r *repository) IncrPasswordResetAttempts(ctx context.Context, userID int) (int, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	codeKey := fmt.Sprintf(keyPasswordReset, userID)
+	attemptsKey := fmt.Sprintf(keyPasswordResetAttempts, userID)
+	ttl, err := redis.Int64(conn.Do("PTTL", codeKey))
+	if err != nil {
+		return 0, err
+	}
+	if ttl <= 0 {
+		ttl = 1
+	}
+
+	conn.Send("MULTI")
+	conn.Send("INCR", attemptsKey)
+	conn.Send("PEXPIRE", attemptsKey, ttl)
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, err
+	}
+	return redis.Int(values[0], nil)
+}
+
+// DeletePasswordResetCode return false if the code was already deleted,
+// so concurrent reset with the same code only succeed once
+func (r *repository) DeletePasswordResetCode(ctx context.Context, userID int) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("DEL", fmt.Sprintf(keyPasswordReset, userID))
+	conn.Send("DEL", fmt.Sprintf(keyPasswordResetAttempts, userID))
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return false, err
+	}
+	deleted, err := redis.Int(values[0], nil)
+	return deleted > 0, err
+}
+
+// GetLoginLock return the longest remaining lockout of the subjects, zero if none is locked
+func (r *repository) GetLoginLock(ctx context.Context, subjects ...string) (time.Duration, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	var longest int64
+	for _, subject := range subjects {
+		ttl, err := redis.Int64(conn.Do("PTTL", fmt.Sprintf(keyLoginLock, subject)))
+		if err != nil {
+			return 0, err
+		}
+		if ttl > longest {
+			longest = ttl
+		}
+	}
+	return time.Duration(longest) * time.Millisecond, nil
+}
+
+// IncrLoginFailures count failed login of the subject, the counter is reset
+// when the window since the first failure is over
+func (r *repository) IncrLoginFailures(ctx context.Context, subject string, window time.Duration) (int, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	key := fmt.Sprintf(keyLoginFailures, subject)
+	failures, err := redis.Int(conn.Do("INCR", key))
+	if err != nil {
+		return 0, err
+	}
+	if failures == 1 {
+		if _, err := conn.Do("PEXPIRE", key, window.Milliseconds()); err != nil {
+			return 0, err
+		}
+	}
+	return failures, nil
+}
+
+func (r *repository) LockLogin(ctx context.Context, subject string, duration time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("SET", fmt.Sprintf(keyLoginLock, subject), 1, "PX", duration.Milliseconds())
+	return err
+}
+
+func (r *repository) ResetLoginFailures(ctx context.Context, subject string) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("DEL", fmt.Sprintf(keyLoginFailures, subject))
+	return err
+}
+
+func (r *repository) StorePreAuthToken(ctx context.Context, tokenHash string, preAuth PreAuth, ttl time.Duration) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	value, err := json.Marshal(preAuth)
+	if err != nil {
+		return err
+	}
+	_, err = conn.Do("SET", fmt.Sprintf(keyPreAuth, tokenHash), value, "EX", int64(ttl.Seconds()))
+	return err
+}
+
+// GetPreAuthToken return redis.ErrNil if the token is unknown or expired
+func (r *repository) GetPreAuthToken(ctx context.Context, tokenHash string) (PreAuth, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return PreAuth{}, err
+	}
+	defer conn.Close()
+
+	value, err := redis.Bytes(conn.Do("GET", fmt.Sprintf(keyPreAuth, tokenHash)))
+	if err != nil {
+		return PreAuth{}, err
+	}
+	var preAuth PreAuth
+	err = json.Unmarshal(value, &preAuth)
+	return preAuth, err
+}
+
+func (r *repository) IncrPreAuthAttempts(ctx context.Context, tokenHash string, ttl time.Duration) (int, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	key := fmt.Sprintf(keyPreAuthAttempts, tokenHash)
+	conn.Send("MULTI")
+	conn.Send("INCR", key)
+	conn.Send("EXPIRE", key, int64(ttl.Seconds()))
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return 0, err
+	}
+	return redis.Int(values[0], nil)
+}
+
+// DeletePreAuthToken return false if the token was already used
+func (r *repository) DeletePreAuthToken(ctx context.Context, tokenHash string) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	conn.Send("MULTI")
+	conn.Send("DEL", fmt.Sprintf(keyPreAuth, tokenHash))
+	conn.Send("DEL", fmt.Sprintf(keyPreAuthAttempts, tokenHash))
+	values, err := redis.Values(conn.Do("EXEC"))
+	if err != nil {
+		return false, err
+	}
+	deleted, err := redis.Int(values[0], nil)
+	return deleted > 0, err
+}
+
+// MarkTOTPCodeUsed return false if the code was already used within ttl
+func (r *repository) MarkTOTPCodeUsed(ctx context.Context, userID int, code string, ttl time.Duration) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	_, err = redis.String(conn.Do("SET", fmt.Sprintf(keyTOTPUsed, userID, code), 1, "EX", int64(ttl.Seconds()), "NX"))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// ShouldTouchSession return true at most once per interval for the session
+func (r *repository) ShouldTouchSession(ctx context.Context, id string, interval time.Duration) (bool, error) {
+	return r.setThrottle(ctx, fmt.Sprintf(keySessionSeen, id), interval)
+}
+
+// ShouldTouchAPIKey return true at most once per interval for the API key
+func (r *repository) ShouldTouchAPIKey(ctx context.Context, id int, interval time.Duration) (bool, error) {
+	return r.setThrottle(ctx, fmt.Sprintf(keyAPIKeySeen, id), interval)
+}
+
+// setThrottle set the key for interval, return false if it is already set
+func (r *repository) setThrottle(ctx context.Context, key string, interval time.Duration) (bool, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return false, err
+	}
+	defer conn.Close()
+
+	_, err = redis.String(conn.Do("SET", key, 1, "PX", interval.Milliseconds(), "NX"))
+	if err == redis.ErrNil {
+		return false, nil
+	}
+	return err == nil, err
+}
+
+// revokeTokenFamily put every live access token of the family into the denylist
+// for the rest of its lifetime and flag the family so it can not be refreshed
+func revokeTokenFamily(conn redis.Conn, familyID string, ttl time.Duration) error {
+	familyTokensKey := fmt.Sprintf(keyFamilyTokens, familyID)
+	tokenIDs, err := redis.Strings(conn.Do("SMEMBERS", familyTokensKey))
+	if err != nil {
+		return err
+	}
+	// the family set expires with its latest refresh token, whose lifetime
+	// follow the client and may exceed ttl
+	familyTTL, err := redis.Int64(conn.Do("PTTL", familyTokensKey))
+	if err != nil {
+		return err
+	}
+	revokedTTL := int64(ttl / time.Millisecond)
+	if familyTTL > revokedTTL {
+		revokedTTL = familyTTL
+	}
+
+	// remaining lifetime of each token, -2 when it is already expired
+	for _, tokenID := range tokenIDs {
+		conn.Send("PTTL", fmt.Sprintf(keyToken, tokenID))
+	}
+	conn.Flush()
+	remaining := make([]int64, len(tokenIDs))
+	for i := range tokenIDs {
+		if remaining[i], err = redis.Int64(conn.Receive()); err != nil {
+			return err
+		}
+	}
+
+	conn.Send("MULTI")
+	conn.Send("SET", fmt.Sprintf(keyRevokedFamily, familyID), 1, "PX", revokedTTL)
+	for i, tokenID := range tokenIDs {
+		if remaining[i] > 0 {
+			conn.Send("SET", fmt.Sprintf(keyRevokedToken, tokenID), 1, "PX", remaining[i])
+		}
+		conn.Send("DEL", fmt.Sprintf(keyToken, tokenID))
+	}
+	conn.Send("DEL", familyTokensKey)
+	_, err = conn.Do("EXEC")
+	return err
+}
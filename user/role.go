@@ -0,0 +1,84 @@
+package user
+
+import "github.com/booking-man-be/lib/server"
+
+// Role of the user, it decide which permissions are granted
+type Role string
+
+const (
+	RoleCustomer      Role = "customer"
+	RoleVenueStaff    Role = "venue_staff"
+	RoleVenueAdmin    Role = "venue_admin"
+	RolePlatformAdmin Role = server.RolePlatformAdmin
+)
+
+// Permission name used in (auth.permission) annotation of the proto files
+const (
+	// PermissionBookingCreate allow to book a resource
+	PermissionBookingCreate = server.PermissionBookingCreate
+	// PermissionBookingManage allow to manage bookings of a venue
+	PermissionBookingManage = server.PermissionBookingManage
+	// PermissionVenueManage allow to manage venues and its resources
+	PermissionVenueManage = "venue:manage"
+	// PermissionUserManage allow to manage other users
+	PermissionUserManage = "user:manage"
+)
+
+var rolePermissions = map[Role][]string{
+	RoleCustomer: {
+		PermissionBookingCreate,
+	},
+	RoleVenueStaff: {
+		PermissionBookingCreate,
+		PermissionBookingManage,
+	},
+	RoleVenueAdmin: {
+		PermissionBookingCreate,
+		PermissionBookingManage,
+		PermissionVenueManage,
+	},
+	RolePlatformAdmin: {
+		PermissionBookingCreate,
+		PermissionBookingManage,
+		PermissionVenueManage,
+		PermissionUserManage,
+	},
+}
+
+// IsValid check whether the role is known
+func (r Role) IsValid() bool {
+	_, ok := rolePermissions[r]
+	return ok
+}
+
+// Permissions return the permissions granted to the role, booking is
+// not allowed until the user verify the email
+func (r Role) Permissions(emailVerified bool) []string {
+	if emailVerified {
+		return rolePermissions[r]
+	}
+	var permissions []string
+	for _, p := range rolePermissions[r] {
+		if p != PermissionBookingCreate {
+			permissions = append(permissions, p)
+		}
+	}
+	return permissions
+}
+
+// restrictPermissions keep only the permissions listed in scopes, empty scopes keep everything
+func restrictPermissions(permissions, scopes []string) []string {
+	if len(scopes) == 0 {
+		return permissions
+	}
+	var restricted []string
+	for _, p := range permissions {
+		for _, scope := range scopes {
+			if p == scope {
+				restricted = append(restricted, p)
+				break
+			}
+		}
+	}
+	return restricted
+}
@@ -1,15 +1,657 @@
 package user
 
+import (
+	"context"
+	"crypto/subtle"
+	"errors"
+	"fmt"
+	"net/mail"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/booking-man-be/client"
+	"github.com/booking-man-be/config"
+	"github.com/booking-man-be/lib/firebase"
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/mailer"
+	"github.com/booking-man-be/lib/server"
+	"github.com/gomodule/redigo/redis"
+	"golang.org/x/crypto/bcrypt"
+	"gorm.io/gorm"
+)
+
+const (
+	// passwordResetCodeLength is number of digit of password reset code
+	passwordResetCodeLength = 8
+	// maxPasswordResetAttempts invalidate the code after this many wrong guess
+	maxPasswordResetAttempts = 5
+)
+
+const (
+	minPasswordLength = 8
+	// bcrypt only uses the first 72 bytes of the password
+	maxPasswordLength = 72
+)
+
+// minTokenSecretLength is the shortest HS256 key accepted, a shorter one can be guessed
+const minTokenSecretLength = 32
+
+var usernameRegex = regexp.MustCompile(`^[a-zA-Z0-9_.]{3,30}$`)
+
+// usernameInvalidChars is stripped from email to build generated username
+var usernameInvalidChars = regexp.MustCompile(`[^a-zA-Z0-9_.]`)
+
+// dummyPasswordHash is compared when the login is unknown so the
+// response time does not reveal whether the account exists
+var dummyPasswordHash, _ = bcrypt.GenerateFromPassword([]byte("booking-man-dummy"), bcrypt.DefaultCost)
+
 type service struct {
-	repo Repository
+	repo                 Repository
+	mailer               mailer.Mailer
+	firebase             firebase.Verifier
+	clients              client.Service
+	tokenSecret          []byte
+	tokenTTL             time.Duration
+	refreshTokenTTL      time.Duration
+	emailVerificationTTL time.Duration
+	passwordResetTTL     time.Duration
+	preAuthTokenTTL      time.Duration
+	totpIssuer           string
+	// accountDeletionGracePeriod is kept before deleted user is anonymised
+	accountDeletionGracePeriod time.Duration
+	loginGuard                 loginGuard
 }
 
 type Service interface {
+	RegisterUser(ctx context.Context, req RegisterUser) (User, error)
+	Login(ctx context.Context, login, password string, clientInfo server.ClientInfo) (LoginToken, error)
+	LoginWithFirebase(ctx context.Context, idToken string, clientInfo server.ClientInfo) (LoginToken, error)
+	RefreshToken(ctx context.Context, refreshToken string, clientInfo server.ClientInfo) (LoginToken, error)
+	Authenticate(ctx context.Context, token string) (server.AuthInfo, error)
+	Logout(ctx context.Context, sessionID string) error
+	LogoutAll(ctx context.Context, userID int) error
+	SetUserRole(ctx context.Context, userID int, role Role) error
+	VerifyEmail(ctx context.Context, token string) error
+	ResendVerificationEmail(ctx context.Context, userID int) error
+	RequestPasswordReset(ctx context.Context, email string) error
+	ResetPassword(ctx context.Context, email, code, newPassword string) error
+
+	GetUser(ctx context.Context, userID int) (User, error)
+	UpdateProfile(ctx context.Context, userID int, req UpdateProfile) (User, error)
+	ChangePassword(ctx context.Context, userID int, sessionID, currentPassword, newPassword string) error
+	DeleteAccount(ctx context.Context, userID int, password string) error
+	AnonymizeDeletedUsers(ctx context.Context) (int, error)
+
+	EnrollTOTP(ctx context.Context, userID int) (TOTPEnrollment, error)
+	ConfirmTOTP(ctx context.Context, userID int, code string) ([]string, error)
+	DisableTOTP(ctx context.Context, userID int, code string) error
+	LoginTOTP(ctx context.Context, preAuthToken, code string, clientInfo server.ClientInfo) (LoginToken, error)
+
+	ListSessions(ctx context.Context, userID int) ([]Session, error)
+	RevokeSession(ctx context.Context, userID int, sessionID string) error
+
+	CreateAPIKey(ctx context.Context, userID int, req CreateAPIKey) (APIKey, string, error)
+	ListAPIKeys(ctx context.Context, userID int) ([]APIKey, error)
+	RevokeAPIKey(ctx context.Context, userID, id int) error
+	AuthenticateAPIKey(ctx context.Context, key string) (server.AuthInfo, error)
 }
 
-func NewService(repo Repository) Service {
+// NewService return an error when the token secret is too short to sign access token safely
+func NewService(repo Repository, mailer mailer.Mailer, firebase firebase.Verifier, clients client.Service, cfg config.Config) (Service, error) {
+	if len(cfg.TokenSecret) < minTokenSecretLength {
+		return nil, fmt.Errorf("TOKEN_SECRET must be at least %d bytes", minTokenSecretLength)
+	}
 	return &service{
-		repo: repo,
+		repo:                 repo,
+		mailer:               mailer,
+		firebase:             firebase,
+		clients:              clients,
+		tokenSecret:          []byte(cfg.TokenSecret),
+		tokenTTL:             time.Duration(cfg.TokenExpiresIn) * time.Minute,
+		refreshTokenTTL:      time.Duration(cfg.RefreshTokenExpiresIn) * time.Hour,
+		emailVerificationTTL: time.Duration(cfg.EmailVerificationExpiresIn) * time.Hour,
+		passwordResetTTL:     time.Duration(cfg.PasswordResetExpiresIn) * time.Minute,
+		preAuthTokenTTL:      time.Duration(cfg.PreAuthTokenExpiresIn) * time.Minute,
+		totpIssuer:           cfg.TOTPIssuer,
+
+		accountDeletionGracePeriod: time.Duration(cfg.AccountDeletionGracePeriod) * 24 * time.Hour,
+		loginGuard: loginGuard{
+			maxFailures:      cfg.LoginMaxFailures,
+			maxFailuresPerIP: cfg.LoginMaxFailuresPerIP,
+			window:           time.Duration(cfg.LoginFailureWindow) * time.Minute,
+			lockoutBase:      time.Duration(cfg.LoginLockoutBase) * time.Second,
+			lockoutMax:       time.Duration(cfg.LoginLockoutMax) * time.Minute,
+		},
+	}, nil
+}
+
+// RegisterUser validate the request, hash the password and store the new user
+func (s *service) RegisterUser(ctx context.Context, req RegisterUser) (User, error) {
+	req.Email = strings.ToLower(strings.TrimSpace(req.Email))
+	req.Username = strings.TrimSpace(req.Username)
+	if err := validateRegisterUser(req); err != nil {
+		return User{}, err
+	}
+
+	exist, err := s.repo.IsEmailExist(ctx, req.Email)
+	if err != nil {
+		return User{}, internalError(err)
+	}
+	if exist {
+		return User{}, ErrEmailAlreadyExists
+	}
+
+	exist, err = s.repo.IsUsernameExist(ctx, req.Username)
+	if err != nil {
+		return User{}, internalError(err)
+	}
+	if exist {
+		return User{}, ErrUsernameTaken
+	}
+
+	hash, err := hashPassword(req.Password)
+	if err != nil {
+		return User{}, internalError(err)
+	}
+
+	user := User{
+		Email:        req.Email,
+		Username:     req.Username,
+		PasswordHash: hash,
+		Role:         RoleCustomer,
+	}
+	// unique index still guard concurrent registration with the same email or username
+	if err := s.repo.CreateUser(ctx, &user); err != nil {
+		if err == ErrEmailAlreadyExists || err == ErrUsernameTaken {
+			return User{}, err
+		}
+		return User{}, internalError(err)
+	}
+
+	// the user can ask for another email, so failure here must not fail the registration
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		logger.Errorf("[user] failed to send verification email to user %d, %v", user.ID, err)
+	}
+
+	return user, nil
+}
+
+// VerifyEmail consume the one time token sent by sendVerificationEmail
+func (s *service) VerifyEmail(ctx context.Context, token string) error {
+	if token == "" {
+		return ErrInvalidVerificationToken
+	}
+	userID, err := s.repo.ConsumeEmailVerificationToken(ctx, hashToken(token))
+	if err == redis.ErrNil {
+		return ErrInvalidVerificationToken
+	}
+	if err != nil {
+		return internalError(err)
+	}
+
+	if err := s.repo.MarkEmailVerified(ctx, userID, time.Now()); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// ResendVerificationEmail send new verification token, the previous one stay valid until it expires
+func (s *service) ResendVerificationEmail(ctx context.Context, userID int) error {
+	user, err := s.repo.GetUserByID(ctx, userID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrUserNotFound
+	}
+	if err != nil {
+		return internalError(err)
+	}
+	if user.EmailVerifiedAt != nil {
+		return ErrEmailAlreadyVerified
+	}
+
+	if err := s.sendVerificationEmail(ctx, user); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+func (s *service) sendVerificationEmail(ctx context.Context, user User) error {
+	token, err := newOpaqueToken()
+	if err != nil {
+		return err
+	}
+	if err := s.repo.StoreEmailVerificationToken(ctx, hashToken(token), user.ID, s.emailVerificationTTL); err != nil {
+		return err
+	}
+
+	return s.mailer.Send(ctx, mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Verify your Booking Man email",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this token to verify your email address:\n\n%s\n\nThe token expires in %s.\n",
+			user.Username, token, s.emailVerificationTTL),
+	})
+}
+
+// RequestPasswordReset email a reset code to the user, unknown email is
+// silently ignored so the caller can not tell whether the account exists
+func (s *service) RequestPasswordReset(ctx context.Context, email string) error {
+	user, err := s.repo.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return nil
+	}
+	if err != nil {
+		return internalError(err)
+	}
+
+	code, err := newNumericCode(passwordResetCodeLength)
+	if err != nil {
+		return internalError(err)
+	}
+	if err := s.repo.StorePasswordResetCode(ctx, user.ID, hashToken(code), s.passwordResetTTL); err != nil {
+		return internalError(err)
+	}
+
+	err = s.mailer.Send(ctx, mailer.Message{
+		To:      []string{user.Email},
+		Subject: "Reset your Booking Man password",
+		Body: fmt.Sprintf("Hi %s,\n\nUse this code to reset your password:\n\n%s\n\nThe code expires in %s. If you did not ask for it, ignore this email.\n",
+			user.Username, code, s.passwordResetTTL),
+	})
+	if err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// ResetPassword replace the password using the emailed code, the code can only
+// be used once and every existing session of the user is revoked
+func (s *service) ResetPassword(ctx context.Context, email, code, newPassword string) error {
+	if err := validatePassword(newPassword); err != nil {
+		return err
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, strings.ToLower(strings.TrimSpace(email)))
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return ErrInvalidResetCode
+	}
+	if err != nil {
+		return internalError(err)
+	}
+
+	codeHash, err := s.repo.GetPasswordResetCode(ctx, user.ID)
+	if err == redis.ErrNil {
+		return ErrInvalidResetCode
+	}
+	if err != nil {
+		return internalError(err)
+	}
+
+	if subtle.ConstantTimeCompare([]byte(codeHash), []byte(hashToken(code))) != 1 {
+		attempts, err := s.repo.IncrPasswordResetAttempts(ctx, user.ID)
+		if err != nil {
+			return internalError(err)
+		}
+		if attempts >= maxPasswordResetAttempts {
+			if _, err := s.repo.DeletePasswordResetCode(ctx, user.ID); err != nil {
+				return internalError(err)
+			}
+		}
+		return ErrInvalidResetCode
+	}
+
+	deleted, err := s.repo.DeletePasswordResetCode(ctx, user.ID)
+	if err != nil {
+		return internalError(err)
+	}
+	if !deleted {
+		return ErrInvalidResetCode
+	}
+
+	hash, err := hashPassword(newPassword)
+	if err != nil {
+		return internalError(err)
+	}
+	if err := s.repo.UpdatePassword(ctx, user.ID, hash); err != nil {
+		return internalError(err)
+	}
+	return s.LogoutAll(ctx, user.ID)
+}
+
+// Login check the credentials and issue new token pair, failed attempts are
+// counted per account and per client IP which are locked once over the limit
+func (s *service) Login(ctx context.Context, login, password string, clientInfo server.ClientInfo) (LoginToken, error) {
+	login = strings.ToLower(strings.TrimSpace(login))
+	if login == "" || password == "" {
+		return LoginToken{}, ErrInvalidCredentials
+	}
+
+	app, err := s.clients.Authorize(ctx, clientInfo.ClientID, client.GrantPassword)
+	if err != nil {
+		return LoginToken{}, err
+	}
+
+	account, clientIP := loginSubjects(login, clientInfo.IP)
+	if err := s.checkLoginLock(ctx, account, clientIP); err != nil {
+		return LoginToken{}, err
+	}
+
+	user, err := s.repo.GetUserByLogin(ctx, login)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		bcrypt.CompareHashAndPassword(dummyPasswordHash, []byte(password))
+		return LoginToken{}, s.recordLoginFailure(ctx, account, clientIP)
+	}
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	if bcrypt.CompareHashAndPassword([]byte(user.PasswordHash), []byte(password)) != nil {
+		return LoginToken{}, s.recordLoginFailure(ctx, account, clientIP)
+	}
+
+	if err := s.repo.ResetLoginFailures(ctx, account); err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	return s.completeLogin(ctx, user, clientInfo, app, client.GrantPassword)
+}
+
+// LoginWithFirebase verify the Firebase ID token and issue our own token pair,
+// the Firebase account is linked to the user with the same verified email
+// or a new user is created
+func (s *service) LoginWithFirebase(ctx context.Context, idToken string, clientInfo server.ClientInfo) (LoginToken, error) {
+	app, err := s.clients.Authorize(ctx, clientInfo.ClientID, client.GrantFirebase)
+	if err != nil {
+		return LoginToken{}, err
+	}
+
+	claims, err := s.firebase.Verify(ctx, idToken)
+	if err != nil {
+		logger.Debugf("[user] invalid firebase token, %v", err)
+		return LoginToken{}, ErrInvalidFirebaseToken
+	}
+
+	user, err := s.repo.GetUserByFirebaseUID(ctx, claims.Subject)
+	if err == nil {
+		return s.completeLogin(ctx, user, clientInfo, app, client.GrantFirebase)
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return LoginToken{}, internalError(err)
+	}
+
+	user, err = s.linkFirebaseUser(ctx, claims)
+	if err != nil {
+		return LoginToken{}, err
+	}
+	return s.completeLogin(ctx, user, clientInfo, app, client.GrantFirebase)
+}
+
+func (s *service) linkFirebaseUser(ctx context.Context, claims firebase.Claims) (User, error) {
+	email := strings.ToLower(strings.TrimSpace(claims.Email))
+	if email == "" {
+		return User{}, ErrFirebaseEmailRequired
+	}
+
+	user, err := s.repo.GetUserByEmail(ctx, email)
+	if err == nil {
+		// linking unverified email would let anybody take over the account
+		if !claims.EmailVerified {
+			return User{}, ErrFirebaseEmailUnlinked
+		}
+		if err := s.repo.LinkFirebaseUID(ctx, user.ID, claims.Subject); err != nil {
+			return User{}, internalError(err)
+		}
+		if user.EmailVerifiedAt == nil {
+			now := time.Now()
+			if err := s.repo.MarkEmailVerified(ctx, user.ID, now); err != nil {
+				return User{}, internalError(err)
+			}
+			user.EmailVerifiedAt = &now
+		}
+		return user, nil
+	}
+	if !errors.Is(err, gorm.ErrRecordNotFound) {
+		return User{}, internalError(err)
+	}
+
+	uid := claims.Subject
+	user = User{
+		Email:       email,
+		Role:        RoleCustomer,
+		FirebaseUID: &uid,
+	}
+	if claims.EmailVerified {
+		now := time.Now()
+		user.EmailVerifiedAt = &now
+	}
+	// empty password hash never match, so the user can only login through Firebase
+	// until the password is reset
+	for attempt := 0; ; attempt++ {
+		if user.Username, err = generateUsername(email); err != nil {
+			return User{}, internalError(err)
+		}
+		err = s.repo.CreateUser(ctx, &user)
+		if err != ErrUsernameTaken || attempt == 2 {
+			break
+		}
+	}
+	if err == ErrEmailAlreadyExists || err == ErrUsernameTaken {
+		return User{}, err
+	}
+	if err != nil {
+		return User{}, internalError(err)
+	}
+	return user, nil
+}
+
+// RefreshToken exchange refresh token for a new token pair in the same family,
+// presenting an already rotated refresh token revoke the whole family. The
+// refresh token can only be used by the client it was issued to
+func (s *service) RefreshToken(ctx context.Context, refreshToken string, clientInfo server.ClientInfo) (LoginToken, error) {
+	if refreshToken == "" {
+		return LoginToken{}, ErrInvalidRefreshToken
+	}
+	app, err := s.clients.Authorize(ctx, clientInfo.ClientID, client.GrantRefreshToken)
+	if err != nil {
+		return LoginToken{}, err
+	}
+
+	tokenHash := hashToken(refreshToken)
+
+	record, err := s.repo.ConsumeRefreshToken(ctx, tokenHash)
+	if err == redis.ErrNil {
+		return LoginToken{}, s.detectRefreshTokenReuse(ctx, tokenHash)
+	}
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+
+	if err := s.repo.StoreRotatedRefreshToken(ctx, tokenHash, record, record.ttl(s.refreshTokenTTL)); err != nil {
+		return LoginToken{}, internalError(err)
+	}
+
+	revoked, err := s.repo.IsTokenFamilyRevoked(ctx, record.FamilyID)
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	if revoked || record.ClientID != app.ID {
+		return LoginToken{}, ErrInvalidRefreshToken
+	}
+
+	// reload the user so role change is reflected in the new token
+	user, err := s.repo.GetUserByID(ctx, record.UserID)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return LoginToken{}, ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+
+	s.touchSession(ctx, record.FamilyID, clientInfo.IP)
+	token, err := s.issueToken(ctx, user, record.FamilyID, app)
+	if err != nil {
+		return LoginToken{}, err
+	}
+	s.extendSession(ctx, record.FamilyID, time.Now().Add(app.RefreshTokenTTL(s.refreshTokenTTL)))
+	return token, nil
+}
+
+// detectRefreshTokenReuse is called for unknown refresh token, if it was
+// rotated before then somebody replay it and the family must be revoked
+func (s *service) detectRefreshTokenReuse(ctx context.Context, tokenHash string) error {
+	record, err := s.repo.GetRotatedRefreshToken(ctx, tokenHash)
+	if err == redis.ErrNil {
+		return ErrInvalidRefreshToken
+	}
+	if err != nil {
+		return internalError(err)
 	}
 
+	logger.Warnf("[user] refresh token reuse detected for user %d, revoking token family %s", record.UserID, record.FamilyID)
+	if err := s.revokeSession(ctx, record.FamilyID); err != nil {
+		return internalError(err)
+	}
+	return ErrRefreshTokenReused
+}
+
+// Authenticate verify the access token and make sure it is not revoked
+func (s *service) Authenticate(ctx context.Context, token string) (server.AuthInfo, error) {
+	claims, err := parseToken(s.tokenSecret, token)
+	if err != nil {
+		return server.AuthInfo{}, ErrInvalidToken
+	}
+	userID, err := claims.UserID()
+	if err != nil {
+		return server.AuthInfo{}, ErrInvalidToken
+	}
+
+	revoked, err := s.repo.IsTokenRevoked(ctx, claims.Id)
+	if err != nil {
+		return server.AuthInfo{}, internalError(err)
+	}
+	if revoked {
+		return server.AuthInfo{}, ErrTokenRevoked
+	}
+
+	s.touchSession(ctx, claims.FamilyID, server.ClientInfoFromContext(ctx).IP)
+	return server.AuthInfo{
+		UserID:      userID,
+		TokenID:     claims.Id,
+		SessionID:   claims.FamilyID,
+		ClientID:    claims.ClientID,
+		Role:        string(claims.Role),
+		Permissions: restrictPermissions(claims.Role.Permissions(claims.EmailVerified), strings.Fields(claims.Scope)),
+	}, nil
+}
+
+// Logout revoke the access token family of the session together with its refresh token
+func (s *service) Logout(ctx context.Context, sessionID string) error {
+	if err := s.revokeSession(ctx, sessionID); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// LogoutAll revoke every session of the user
+func (s *service) LogoutAll(ctx context.Context, userID int) error {
+	if err := s.repo.RevokeUserTokens(ctx, userID, s.refreshTokenTTL); err != nil {
+		return internalError(err)
+	}
+	if err := s.repo.MarkUserSessionsRevoked(ctx, userID, time.Now()); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// SetUserRole change the role of the user, it take effect on the next token refresh
+func (s *service) SetUserRole(ctx context.Context, userID int, role Role) error {
+	if !role.IsValid() {
+		return ErrInvalidRole
+	}
+	found, err := s.repo.UpdateUserRole(ctx, userID, role)
+	if err != nil {
+		return internalError(err)
+	}
+	if !found {
+		return ErrUserNotFound
+	}
+	return nil
+}
+
+// issueToken sign new access token, generate refresh token and store both in redis,
+// lifetime and scope of the tokens follow the client
+func (s *service) issueToken(ctx context.Context, user User, familyID string, app client.Client) (LoginToken, error) {
+	now := time.Now()
+	tokenID := newTokenID()
+	tokenTTL := app.TokenTTL(s.tokenTTL)
+	refreshTokenTTL := app.RefreshTokenTTL(s.refreshTokenTTL)
+	token, err := signToken(s.tokenSecret, user, app, familyID, tokenID, now, tokenTTL)
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	refreshToken, err := newOpaqueToken()
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+
+	err = s.repo.StoreRefreshToken(ctx, hashToken(refreshToken), RefreshToken{
+		UserID:    user.ID,
+		TokenID:   tokenID,
+		FamilyID:  familyID,
+		ClientID:  app.ID,
+		ExpiresIn: int64(refreshTokenTTL.Seconds()),
+	}, refreshTokenTTL)
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	// the family is stored last so it never expire before its refresh token,
+	// revoking the family rely on that
+	if err := s.repo.StoreToken(ctx, user.ID, familyID, tokenID, tokenTTL, refreshTokenTTL); err != nil {
+		return LoginToken{}, internalError(err)
+	}
+
+	return LoginToken{
+		Token:          token,
+		RefreshToken:   refreshToken,
+		TokenExpiresIn: int64(tokenTTL.Seconds()),
+		TokenID:        tokenID,
+	}, nil
+}
+
+// generateUsername build username from the local part of email with random suffix
+func generateUsername(email string) (string, error) {
+	name := usernameInvalidChars.ReplaceAllString(strings.SplitN(email, "@", 2)[0], "")
+	if len(name) > 20 {
+		name = name[:20]
+	}
+	if name == "" {
+		name = "user"
+	}
+	suffix, err := newNumericCode(6)
+	if err != nil {
+		return "", err
+	}
+	return name + "_" + suffix, nil
+}
+
+func validateRegisterUser(req RegisterUser) error {
+	if addr, err := mail.ParseAddress(req.Email); err != nil || addr.Address != req.Email {
+		return ErrInvalidEmail
+	}
+	if !usernameRegex.MatchString(req.Username) {
+		return ErrInvalidUsername
+	}
+	return validatePassword(req.Password)
+}
+
+func validatePassword(password string) error {
+	if len(password) < minPasswordLength || len(password) > maxPasswordLength {
+		return ErrInvalidPassword
+	}
+	return nil
+}
+
+func hashPassword(password string) (string, error) {
+	hash, err := bcrypt.GenerateFromPassword([]byte(password), bcrypt.DefaultCost)
+	if err != nil {
+		return "", err
+	}
+	return string(hash), nil
 }
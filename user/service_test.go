@@ -0,0 +1,275 @@
+package user
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/booking-man-be/client"
+	"github.com/booking-man-be/config"
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+)
+
+const testTokenSecret = "0123456789abcdef0123456789abcdef"
+
+// testRepository keep the redis side on miniredis and the database side in memory
+type testRepository struct {
+	Repository
+	redis *miniredis.Miniredis
+
+	mu       sync.Mutex
+	users    map[int]User
+	sessions map[string]Session
+	apiKeys  map[int]APIKey
+}
+
+func (r *testRepository) GetUserByID(ctx context.Context, id int) (User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok {
+		return User{}, gorm.ErrRecordNotFound
+	}
+	return user, nil
+}
+
+func (r *testRepository) GetUserByLogin(ctx context.Context, login string) (User, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, user := range r.users {
+		if user.Email == login || user.Username == login {
+			return user, nil
+		}
+	}
+	return User{}, gorm.ErrRecordNotFound
+}
+
+func (r *testRepository) UpdateUser(ctx context.Context, id int, fields map[string]interface{}) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user := r.users[id]
+	for field, value := range fields {
+		switch field {
+		case "role":
+			user.Role = value.(Role)
+		case "recovery_codes":
+			user.RecoveryCodes = value.(string)
+		}
+	}
+	r.users[id] = user
+	return nil
+}
+
+func (r *testRepository) ReplaceRecoveryCodes(ctx context.Context, id int, old, new string) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user, ok := r.users[id]
+	if !ok || user.RecoveryCodes != old {
+		return false, nil
+	}
+	user.RecoveryCodes = new
+	r.users[id] = user
+	return true, nil
+}
+
+func (r *testRepository) CreateSession(ctx context.Context, session *Session) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.sessions[session.ID] = *session
+	return nil
+}
+
+func (r *testRepository) ListDeviceSessions(ctx context.Context, userID int, deviceID string) ([]Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.DeviceID == deviceID && session.RevokedAt == nil {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *testRepository) ListActiveSessions(ctx context.Context, userID int, now time.Time) ([]Session, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var sessions []Session
+	for _, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil && session.ExpiresAt.After(now) {
+			sessions = append(sessions, session)
+		}
+	}
+	return sessions, nil
+}
+
+func (r *testRepository) TouchSession(ctx context.Context, id, ip string, at time.Time) error {
+	return nil
+}
+
+func (r *testRepository) ExtendSession(ctx context.Context, id string, expiresAt time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.sessions[id]
+	session.ExpiresAt = expiresAt
+	r.sessions[id] = session
+	return nil
+}
+
+func (r *testRepository) MarkSessionRevoked(ctx context.Context, id string, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	session := r.sessions[id]
+	session.RevokedAt = &at
+	r.sessions[id] = session
+	return nil
+}
+
+func (r *testRepository) MarkUserSessionsRevoked(ctx context.Context, userID int, at time.Time) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for id, session := range r.sessions {
+		if session.UserID == userID && session.RevokedAt == nil {
+			session.RevokedAt = &at
+			r.sessions[id] = session
+		}
+	}
+	return nil
+}
+
+func (r *testRepository) CreateAPIKey(ctx context.Context, key *APIKey) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key.ID = len(r.apiKeys) + 1
+	r.apiKeys[key.ID] = *key
+	return nil
+}
+
+func (r *testRepository) CountActiveAPIKeys(ctx context.Context, userID int, now time.Time) (int64, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	var count int64
+	for _, key := range r.apiKeys {
+		if key.UserID == userID && key.RevokedAt == nil && (key.ExpiresAt == nil || key.ExpiresAt.After(now)) {
+			count++
+		}
+	}
+	return count, nil
+}
+
+func (r *testRepository) GetAPIKeyByPrefix(ctx context.Context, prefix string) (APIKey, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range r.apiKeys {
+		if key.Prefix == prefix {
+			return key, nil
+		}
+	}
+	return APIKey{}, gorm.ErrRecordNotFound
+}
+
+func (r *testRepository) RevokeAPIKey(ctx context.Context, userID, id int, at time.Time) (bool, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key, ok := r.apiKeys[id]
+	if !ok || key.UserID != userID || key.RevokedAt != nil {
+		return false, nil
+	}
+	key.RevokedAt = &at
+	r.apiKeys[id] = key
+	return true, nil
+}
+
+func (r *testRepository) TouchAPIKey(ctx context.Context, id int, at time.Time) error {
+	return nil
+}
+
+func (r *testRepository) addUser(user User) User {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	user.ID = len(r.users) + 1
+	r.users[user.ID] = user
+	return user
+}
+
+// testClients authorize the registered clients the same way as client.Service
+type testClients map[string]client.Client
+
+func (c testClients) Authorize(ctx context.Context, clientID string, grant client.GrantType) (client.Client, error) {
+	app, ok := c[clientID]
+	if !ok || !app.Enabled {
+		return client.Client{}, client.ErrUnknownClient
+	}
+	if grant != "" && !app.AllowGrant(grant) {
+		return client.Client{}, client.ErrGrantNotAllowed
+	}
+	return app, nil
+}
+
+var testApps = testClients{
+	"web": {
+		ID:         "web",
+		GrantTypes: "password,refresh_token",
+		Enabled:    true,
+	},
+	"partner": {
+		ID:         "partner",
+		GrantTypes: "password,refresh_token",
+		Scopes:     "booking:create",
+		Enabled:    true,
+	},
+	// mobile keep the user logged in longer than the default 720 hours
+	"mobile": {
+		ID:                    "mobile",
+		GrantTypes:            "password,refresh_token",
+		RefreshTokenExpiresIn: 2160,
+		Enabled:               true,
+	},
+	"kiosk": {
+		ID:                    "kiosk",
+		GrantTypes:            "password,refresh_token",
+		RefreshTokenExpiresIn: 1,
+		Enabled:               true,
+	},
+}
+
+func newTestService(t *testing.T) (*service, *testRepository) {
+	t.Helper()
+	mr, err := miniredis.Run()
+	if err != nil {
+		t.Fatal(err)
+	}
+	t.Cleanup(mr.Close)
+	pool := &redis.Pool{
+		Dial: func() (redis.Conn, error) {
+			return redis.Dial("tcp", mr.Addr())
+		},
+	}
+	t.Cleanup(func() { pool.Close() })
+
+	repo := &testRepository{
+		Repository: NewRepository(nil, pool),
+		redis:      mr,
+		users:      map[int]User{},
+		sessions:   map[string]Session{},
+		apiKeys:    map[int]APIKey{},
+	}
+	svc, err := NewService(repo, nil, nil, testApps, config.Config{
+		TokenSecret:           testTokenSecret,
+		TokenExpiresIn:        15,
+		RefreshTokenExpiresIn: 720,
+		PreAuthTokenExpiresIn: 5,
+		LoginMaxFailures:      5,
+		LoginMaxFailuresPerIP: 20,
+		LoginFailureWindow:    15,
+		LoginLockoutBase:      30,
+		LoginLockoutMax:       60,
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return svc.(*service), repo
+}
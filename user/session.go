@@ -0,0 +1,109 @@
+package user
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	"github.com/booking-man-be/client"
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/server"
+	"gorm.io/gorm"
+)
+
+// sessionTouchInterval limit how often last seen time of a session is written
+const sessionTouchInterval = time.Minute
+
+// maxUserAgentLength is the size of Session.UserAgent column
+const maxUserAgentLength = 255
+
+// startSession record new session of the user and issue its first token pair,
+// previous session on the same device is revoked
+func (s *service) startSession(ctx context.Context, user User, clientInfo server.ClientInfo, app client.Client) (LoginToken, error) {
+	if clientInfo.DeviceID != "" {
+		sessions, err := s.repo.ListDeviceSessions(ctx, user.ID, clientInfo.DeviceID)
+		if err != nil {
+			return LoginToken{}, internalError(err)
+		}
+		for _, session := range sessions {
+			if err := s.revokeSession(ctx, session.ID); err != nil {
+				return LoginToken{}, internalError(err)
+			}
+		}
+	}
+
+	userAgent := clientInfo.UserAgent
+	if len(userAgent) > maxUserAgentLength {
+		userAgent = userAgent[:maxUserAgentLength]
+	}
+	now := time.Now()
+	session := Session{
+		ID:         newTokenID(),
+		UserID:     user.ID,
+		DeviceID:   clientInfo.DeviceID,
+		ClientID:   app.ID,
+		UserAgent:  userAgent,
+		IP:         clientInfo.IP,
+		LastSeenAt: now,
+		ExpiresAt:  now.Add(app.RefreshTokenTTL(s.refreshTokenTTL)),
+		CreatedAt:  now,
+	}
+	if err := s.repo.CreateSession(ctx, &session); err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	return s.issueToken(ctx, user, session.ID, app)
+}
+
+// ListSessions return the active sessions of the user
+func (s *service) ListSessions(ctx context.Context, userID int) ([]Session, error) {
+	sessions, err := s.repo.ListActiveSessions(ctx, userID, time.Now())
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return sessions, nil
+}
+
+// RevokeSession log the user out of one of the devices
+func (s *service) RevokeSession(ctx context.Context, userID int, sessionID string) error {
+	session, err := s.repo.GetSession(ctx, sessionID)
+	if errors.Is(err, gorm.ErrRecordNotFound) || (err == nil && session.UserID != userID) {
+		return ErrSessionNotFound
+	}
+	if err != nil {
+		return internalError(err)
+	}
+
+	if err := s.revokeSession(ctx, sessionID); err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// revokeSession revoke the token family of the session and mark it as revoked,
+// the family keep it revoked for the refresh token lifetime of its client
+func (s *service) revokeSession(ctx context.Context, sessionID string) error {
+	if err := s.repo.RevokeTokenFamily(ctx, sessionID, s.refreshTokenTTL); err != nil {
+		return err
+	}
+	return s.repo.MarkSessionRevoked(ctx, sessionID, time.Now())
+}
+
+// extendSession push back the expiry of the session after its refresh token is
+// rotated, failure is only logged since the new token pair is already issued
+func (s *service) extendSession(ctx context.Context, sessionID string, expiresAt time.Time) {
+	if err := s.repo.ExtendSession(ctx, sessionID, expiresAt); err != nil {
+		logger.Errorf("[user] failed to extend session %s, %v", sessionID, err)
+	}
+}
+
+// touchSession update last seen time and IP of the session, failure is only
+// logged since it must not fail the call being authenticated
+func (s *service) touchSession(ctx context.Context, sessionID, ip string) {
+	touch, err := s.repo.ShouldTouchSession(ctx, sessionID, sessionTouchInterval)
+	if err == nil && touch {
+		err = s.repo.TouchSession(ctx, sessionID, ip, time.Now())
+	}
+	if err != nil {
+		logger.Errorf("[user] failed to update session %s, %v", sessionID, err)
+	}
+}
@@ -0,0 +1,130 @@
+package user
+
+import (
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"strconv"
+	"time"
+
+	"github.com/booking-man-be/client"
+	"github.com/dgrijalva/jwt-go"
+	"github.com/google/uuid"
+)
+
+// tokenIssuer is the iss claim of every access token
+const tokenIssuer = "booking-man"
+
+// RefreshToken is the server side record of an issued refresh token,
+// every token rotated from the same login share one FamilyID
+type RefreshToken struct {
+	UserID   int    `json:"user_id"`
+	TokenID  string `json:"token_id"`
+	FamilyID string `json:"family_id"`
+	ClientID string `json:"client_id"`
+	// ExpiresIn is the lifetime in seconds the client gave the refresh token
+	ExpiresIn int64 `json:"expires_in"`
+}
+
+// ttl return the lifetime of the refresh token, fallback for records stored
+// before the lifetime was recorded
+func (t RefreshToken) ttl(fallback time.Duration) time.Duration {
+	if t.ExpiresIn > 0 {
+		return time.Duration(t.ExpiresIn) * time.Second
+	}
+	return fallback
+}
+
+// PreAuth is the server side record of a pre-auth token, the second login
+// step must come from the same client and grant as the first one
+type PreAuth struct {
+	UserID   int              `json:"user_id"`
+	ClientID string           `json:"client_id"`
+	Grant    client.GrantType `json:"grant"`
+}
+
+// TokenClaims is the payload of signed access token,
+// Subject hold the user ID and Id hold the token ID
+type TokenClaims struct {
+	FamilyID string `json:"fid"`
+	ClientID string `json:"cid"`
+	// Scope is space separated permissions allowed to the client, empty means no restriction
+	Scope string `json:"scope,omitempty"`
+	Role  Role   `json:"role"`
+	// EmailVerified is false until the user verify the email
+	EmailVerified bool `json:"email_verified"`
+	jwt.StandardClaims
+}
+
+// UserID parse the subject claim into user ID
+func (c TokenClaims) UserID() (int, error) {
+	return strconv.Atoi(c.Subject)
+}
+
+func newTokenID() string {
+	return uuid.New().String()
+}
+
+// signToken create HS256 signed access token for the user
+func signToken(secret []byte, user User, app client.Client, familyID, tokenID string, now time.Time, ttl time.Duration) (string, error) {
+	claims := TokenClaims{
+		FamilyID: familyID,
+		ClientID: app.ID,
+		Scope:    app.Scopes,
+		Role:     user.Role,
+
+		EmailVerified: user.EmailVerifiedAt != nil,
+		StandardClaims: jwt.StandardClaims{
+			Id:        tokenID,
+			Subject:   strconv.Itoa(user.ID),
+			Issuer:    tokenIssuer,
+			IssuedAt:  now.Unix(),
+			ExpiresAt: now.Add(ttl).Unix(),
+		},
+	}
+	return jwt.NewWithClaims(jwt.SigningMethodHS256, claims).SignedString(secret)
+}
+
+// parseToken verify signature and expiry of access token
+func parseToken(secret []byte, token string) (TokenClaims, error) {
+	var claims TokenClaims
+	_, err := jwt.ParseWithClaims(token, &claims, func(t *jwt.Token) (interface{}, error) {
+		if t.Method != jwt.SigningMethodHS256 {
+			return nil, errors.New("unexpected signing method")
+		}
+		return secret, nil
+	})
+	if err != nil {
+		return TokenClaims{}, err
+	}
+	return claims, nil
+}
+
+// newOpaqueToken generate url safe random token
+func newOpaqueToken() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
+
+// newNumericCode generate random code of n digits which is easy to type
+func newNumericCode(n int) (string, error) {
+	max := new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+	v, err := rand.Int(rand.Reader, max)
+	if err != nil {
+		return "", err
+	}
+	return fmt.Sprintf("%0*d", n, v), nil
+}
+
+// hashToken is used to store opaque token without keeping the plain value
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
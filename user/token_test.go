@@ -0,0 +1,142 @@
+package user
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/booking-man-be/lib/server"
+)
+
+func TestRefreshTokenRotation(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	user := repo.addUser(User{Email: "ana@example.com", Role: RoleCustomer})
+	clientInfo := server.ClientInfo{ClientID: "web"}
+
+	first, err := s.startSession(ctx, user, clientInfo, testApps["web"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.RefreshToken(ctx, first.RefreshToken, clientInfo)
+	if err != nil {
+		t.Fatalf("RefreshToken() error = %v", err)
+	}
+	if second.RefreshToken == first.RefreshToken || second.TokenID == first.TokenID {
+		t.Fatal("RefreshToken() should issue a new token pair")
+	}
+	if _, err := s.Authenticate(ctx, second.Token); err != nil {
+		t.Errorf("Authenticate(rotated token) error = %v", err)
+	}
+
+	if _, err := s.RefreshToken(ctx, "unknown", clientInfo); err != ErrInvalidRefreshToken {
+		t.Errorf("RefreshToken(unknown) error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	if _, err := s.RefreshToken(ctx, second.RefreshToken, server.ClientInfo{ClientID: "partner"}); err != ErrInvalidRefreshToken {
+		t.Errorf("RefreshToken(other client) error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
+
+func TestRefreshTokenReuseRevokeFamily(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	user := repo.addUser(User{Email: "ana@example.com", Role: RoleCustomer})
+	clientInfo := server.ClientInfo{ClientID: "web"}
+
+	first, err := s.startSession(ctx, user, clientInfo, testApps["web"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	other, err := s.startSession(ctx, user, clientInfo, testApps["web"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	second, err := s.RefreshToken(ctx, first.RefreshToken, clientInfo)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// replaying the rotated token revoke every token of its family
+	if _, err := s.RefreshToken(ctx, first.RefreshToken, clientInfo); err != ErrRefreshTokenReused {
+		t.Fatalf("RefreshToken(reused) error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	if _, err := s.RefreshToken(ctx, second.RefreshToken, clientInfo); err != ErrInvalidRefreshToken {
+		t.Errorf("RefreshToken(latest of revoked family) error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+	for name, token := range map[string]string{"first": first.Token, "second": second.Token} {
+		if _, err := s.Authenticate(ctx, token); err != ErrTokenRevoked {
+			t.Errorf("Authenticate(%s access token) error = %v, want %v", name, err, ErrTokenRevoked)
+		}
+	}
+	claims, err := parseToken(s.tokenSecret, first.Token)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if repo.sessions[claims.FamilyID].RevokedAt == nil {
+		t.Error("session of the reused token should be revoked")
+	}
+
+	// the other session of the user is not affected
+	if _, err := s.Authenticate(ctx, other.Token); err != nil {
+		t.Errorf("Authenticate(other session) error = %v", err)
+	}
+	if _, err := s.RefreshToken(ctx, other.RefreshToken, clientInfo); err != nil {
+		t.Errorf("RefreshToken(other session) error = %v", err)
+	}
+}
+
+func TestRefreshTokenLongLivedClient(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	user := repo.addUser(User{Email: "ana@example.com", Role: RoleCustomer})
+	mobile := server.ClientInfo{ClientID: "mobile"}
+
+	first, err := s.startSession(ctx, user, mobile, testApps["mobile"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	// a later kiosk login must not shorten the sets shared with the mobile session
+	if _, err := s.startSession(ctx, user, server.ClientInfo{ClientID: "kiosk"}, testApps["kiosk"]); err != nil {
+		t.Fatal(err)
+	}
+	repo.redis.FastForward(2 * time.Hour)
+
+	second, err := s.RefreshToken(ctx, first.RefreshToken, mobile)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// past the default lifetime, within the lifetime of the mobile client
+	repo.redis.FastForward(800 * time.Hour)
+	if _, err := s.RefreshToken(ctx, first.RefreshToken, mobile); err != ErrRefreshTokenReused {
+		t.Fatalf("RefreshToken(reused after %s) error = %v, want %v", 800*time.Hour, err, ErrRefreshTokenReused)
+	}
+	repo.redis.FastForward(800 * time.Hour)
+	if _, err := s.RefreshToken(ctx, second.RefreshToken, mobile); err != ErrInvalidRefreshToken {
+		t.Errorf("RefreshToken(revoked family after %s) error = %v, want %v", 800*time.Hour, err, ErrInvalidRefreshToken)
+	}
+
+	other, err := s.startSession(ctx, user, mobile, testApps["mobile"])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.startSession(ctx, user, server.ClientInfo{ClientID: "kiosk"}, testApps["kiosk"]); err != nil {
+		t.Fatal(err)
+	}
+	repo.redis.FastForward(2 * time.Hour)
+	// the session is listed as long as its refresh token live
+	for _, session := range repo.sessions {
+		want := time.Now().Add(time.Hour)
+		if session.ClientID == "mobile" {
+			want = time.Now().Add(2160 * time.Hour)
+		}
+		if session.RevokedAt == nil && (session.ExpiresAt.After(want) || session.ExpiresAt.Before(want.Add(-time.Minute))) {
+			t.Errorf("%s session expires at %s, want %s", session.ClientID, session.ExpiresAt, want)
+		}
+	}
+	if err := s.LogoutAll(ctx, user.ID); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := s.RefreshToken(ctx, other.RefreshToken, mobile); err != ErrInvalidRefreshToken {
+		t.Errorf("RefreshToken(after LogoutAll) error = %v, want %v", err, ErrInvalidRefreshToken)
+	}
+}
@@ -0,0 +1,284 @@
+package user
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base32"
+	"encoding/json"
+	"strings"
+	"time"
+
+	"github.com/booking-man-be/client"
+	"github.com/booking-man-be/lib/server"
+	"github.com/gomodule/redigo/redis"
+	"github.com/pquerna/otp"
+	"github.com/pquerna/otp/totp"
+)
+
+const (
+	// recoveryCodeCount is number of recovery codes generated on confirmation
+	recoveryCodeCount = 10
+	// maxPreAuthAttempts invalidate the pre-auth token after this many wrong code
+	maxPreAuthAttempts = 5
+	// totpPeriod is validity of each TOTP code in seconds
+	totpPeriod = 30
+	// totpSkew accept the code of previous and next period for clock drift
+	totpSkew = 1
+)
+
+var totpValidateOpts = totp.ValidateOpts{
+	Period:    totpPeriod,
+	Skew:      totpSkew,
+	Digits:    otp.DigitsSix,
+	Algorithm: otp.AlgorithmSHA1,
+}
+
+// recoveryCodeEncoding is lowercase base32 without padding, easy to read and type
+var recoveryCodeEncoding = base32.NewEncoding("abcdefghijklmnopqrstuvwxyz234567").WithPadding(base32.NoPadding)
+
+// EnrollTOTP generate new secret for the user, it is not enforced until ConfirmTOTP
+func (s *service) EnrollTOTP(ctx context.Context, userID int) (TOTPEnrollment, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return TOTPEnrollment{}, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return TOTPEnrollment{}, ErrTOTPAlreadyEnabled
+	}
+
+	key, err := totp.Generate(totp.GenerateOpts{
+		Issuer:      s.totpIssuer,
+		AccountName: user.Email,
+		Period:      totpPeriod,
+		Digits:      otp.DigitsSix,
+		Algorithm:   otp.AlgorithmSHA1,
+	})
+	if err != nil {
+		return TOTPEnrollment{}, internalError(err)
+	}
+	if err := s.repo.UpdateUser(ctx, userID, map[string]interface{}{"totp_secret": key.Secret()}); err != nil {
+		return TOTPEnrollment{}, internalError(err)
+	}
+
+	return TOTPEnrollment{
+		Secret: key.Secret(),
+		URI:    key.URL(),
+	}, nil
+}
+
+// ConfirmTOTP enable two factor authentication once the user prove the
+// authenticator app works, it return the plain recovery codes only once
+func (s *service) ConfirmTOTP(ctx context.Context, userID int, code string) ([]string, error) {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return nil, err
+	}
+	if user.TOTPEnabledAt != nil {
+		return nil, ErrTOTPAlreadyEnabled
+	}
+	if user.TOTPSecret == "" {
+		return nil, ErrTOTPNotEnrolled
+	}
+	ok, err := s.verifyTOTPCode(ctx, user, code)
+	if err != nil {
+		return nil, err
+	}
+	if !ok {
+		return nil, ErrInvalidTOTPCode
+	}
+
+	codes, hashed, err := newRecoveryCodes()
+	if err != nil {
+		return nil, internalError(err)
+	}
+	err = s.repo.UpdateUser(ctx, userID, map[string]interface{}{
+		"totp_enabled_at": time.Now(),
+		"recovery_codes":  hashed,
+	})
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return codes, nil
+}
+
+// DisableTOTP turn off two factor authentication, code is either TOTP or recovery code
+func (s *service) DisableTOTP(ctx context.Context, userID int, code string) error {
+	user, err := s.GetUser(ctx, userID)
+	if err != nil {
+		return err
+	}
+	if user.TOTPEnabledAt == nil {
+		return ErrTOTPNotEnabled
+	}
+	ok, err := s.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return err
+	}
+	if !ok {
+		return ErrInvalidTOTPCode
+	}
+
+	err = s.repo.UpdateUser(ctx, userID, map[string]interface{}{
+		"totp_secret":     "",
+		"totp_enabled_at": nil,
+		"recovery_codes":  "",
+	})
+	if err != nil {
+		return internalError(err)
+	}
+	return nil
+}
+
+// LoginTOTP is the second login step, it exchange the pre-auth token and
+// TOTP or recovery code for the real token pair. It must be called by the
+// client which started the login, which is still allowed to use the grant
+func (s *service) LoginTOTP(ctx context.Context, preAuthToken, code string, clientInfo server.ClientInfo) (LoginToken, error) {
+	if preAuthToken == "" {
+		return LoginToken{}, ErrInvalidPreAuth
+	}
+
+	tokenHash := hashToken(preAuthToken)
+	preAuth, err := s.repo.GetPreAuthToken(ctx, tokenHash)
+	if err == redis.ErrNil {
+		return LoginToken{}, ErrInvalidPreAuth
+	}
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	app, err := s.clients.Authorize(ctx, clientInfo.ClientID, preAuth.Grant)
+	if err != nil {
+		return LoginToken{}, err
+	}
+	if app.ID != preAuth.ClientID {
+		return LoginToken{}, ErrInvalidPreAuth
+	}
+
+	user, err := s.GetUser(ctx, preAuth.UserID)
+	if err != nil {
+		return LoginToken{}, err
+	}
+	ok, err := s.verifySecondFactor(ctx, user, code)
+	if err != nil {
+		return LoginToken{}, err
+	}
+	if !ok {
+		attempts, err := s.repo.IncrPreAuthAttempts(ctx, tokenHash, s.preAuthTokenTTL)
+		if err != nil {
+			return LoginToken{}, internalError(err)
+		}
+		if attempts >= maxPreAuthAttempts {
+			if _, err := s.repo.DeletePreAuthToken(ctx, tokenHash); err != nil {
+				return LoginToken{}, internalError(err)
+			}
+			return LoginToken{}, ErrInvalidPreAuth
+		}
+		return LoginToken{}, ErrInvalidTOTPCode
+	}
+
+	deleted, err := s.repo.DeletePreAuthToken(ctx, tokenHash)
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	if !deleted {
+		return LoginToken{}, ErrInvalidPreAuth
+	}
+	return s.startSession(ctx, user, clientInfo, app)
+}
+
+// completeLogin start new session for authenticated user, or issue pre-auth
+// token bound to the client and grant when the user has to enter TOTP code first
+func (s *service) completeLogin(ctx context.Context, user User, clientInfo server.ClientInfo, app client.Client, grant client.GrantType) (LoginToken, error) {
+	if user.TOTPEnabledAt == nil {
+		return s.startSession(ctx, user, clientInfo, app)
+	}
+
+	preAuthToken, err := newOpaqueToken()
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	err = s.repo.StorePreAuthToken(ctx, hashToken(preAuthToken), PreAuth{
+		UserID:   user.ID,
+		ClientID: app.ID,
+		Grant:    grant,
+	}, s.preAuthTokenTTL)
+	if err != nil {
+		return LoginToken{}, internalError(err)
+	}
+	return LoginToken{
+		MFARequired:  true,
+		PreAuthToken: preAuthToken,
+	}, nil
+}
+
+// verifySecondFactor accept TOTP code or one of the unused recovery codes
+func (s *service) verifySecondFactor(ctx context.Context, user User, code string) (bool, error) {
+	code = strings.ToLower(strings.Join(strings.Fields(code), ""))
+	if len(code) == int(otp.DigitsSix) {
+		return s.verifyTOTPCode(ctx, user, code)
+	}
+	return s.useRecoveryCode(ctx, user, code)
+}
+
+// verifyTOTPCode validate the code against the secret, each code is only accepted once
+func (s *service) verifyTOTPCode(ctx context.Context, user User, code string) (bool, error) {
+	ok, err := totp.ValidateCustom(code, user.TOTPSecret, time.Now().UTC(), totpValidateOpts)
+	if err != nil || !ok {
+		return false, nil
+	}
+
+	// the code stay valid for the skew window on both side
+	window := time.Duration(totpPeriod*(2*totpSkew+1)) * time.Second
+	unused, err := s.repo.MarkTOTPCodeUsed(ctx, user.ID, code, window)
+	if err != nil {
+		return false, internalError(err)
+	}
+	return unused, nil
+}
+
+// useRecoveryCode remove the matching recovery code from the user
+func (s *service) useRecoveryCode(ctx context.Context, user User, code string) (bool, error) {
+	if user.RecoveryCodes == "" {
+		return false, nil
+	}
+	var hashed []string
+	if err := json.Unmarshal([]byte(user.RecoveryCodes), &hashed); err != nil {
+		return false, internalError(err)
+	}
+
+	codeHash := hashToken(strings.Replace(code, "-", "", -1))
+	for i, h := range hashed {
+		if h != codeHash {
+			continue
+		}
+		remaining, err := json.Marshal(append(hashed[:i:i], hashed[i+1:]...))
+		if err != nil {
+			return false, internalError(err)
+		}
+		replaced, err := s.repo.ReplaceRecoveryCodes(ctx, user.ID, user.RecoveryCodes, string(remaining))
+		if err != nil {
+			return false, internalError(err)
+		}
+		return replaced, nil
+	}
+	return false, nil
+}
+
+// newRecoveryCodes return the plain codes formatted as xxxxx-xxxxx and JSON array of their hash
+func newRecoveryCodes() ([]string, string, error) {
+	codes := make([]string, recoveryCodeCount)
+	hashed := make([]string, recoveryCodeCount)
+	for i := range codes {
+		b := make([]byte, 6)
+		if _, err := rand.Read(b); err != nil {
+			return nil, "", err
+		}
+		code := recoveryCodeEncoding.EncodeToString(b)[:10]
+		codes[i] = code[:5] + "-" + code[5:]
+		hashed[i] = hashToken(code)
+	}
+	value, err := json.Marshal(hashed)
+	if err != nil {
+		return nil, "", err
+	}
+	return codes, string(value), nil
+}
@@ -0,0 +1,111 @@
+package user
+
+import (
+	"context"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/booking-man-be/client"
+	"github.com/booking-man-be/lib/server"
+)
+
+func TestRecoveryCodeSingleUse(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	codes, hashed, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabledAt := time.Now()
+	user := repo.addUser(User{
+		Email:         "ana@example.com",
+		Role:          RoleCustomer,
+		TOTPSecret:    "JBSWY3DPEHPK3PXP",
+		TOTPEnabledAt: &enabledAt,
+		RecoveryCodes: hashed,
+	})
+	clientInfo := server.ClientInfo{ClientID: "web"}
+	login := func(code string) error {
+		t.Helper()
+		pending, err := s.completeLogin(ctx, user, clientInfo, testApps["web"], client.GrantPassword)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !pending.MFARequired {
+			t.Fatal("completeLogin() should require the second factor")
+		}
+		_, err = s.LoginTOTP(ctx, pending.PreAuthToken, code, clientInfo)
+		return err
+	}
+
+	if err := login(codes[0]); err != nil {
+		t.Fatalf("LoginTOTP(recovery code) error = %v", err)
+	}
+	if err := login(codes[0]); err != ErrInvalidTOTPCode {
+		t.Errorf("LoginTOTP(used recovery code) error = %v, want %v", err, ErrInvalidTOTPCode)
+	}
+	// codes are accepted without dash and in any case
+	if err := login(strings.ToUpper(strings.Replace(codes[1], "-", "", 1))); err != nil {
+		t.Errorf("LoginTOTP(reformatted recovery code) error = %v", err)
+	}
+	if err := login("aaaaa-aaaaa"); err != ErrInvalidTOTPCode {
+		t.Errorf("LoginTOTP(unknown recovery code) error = %v, want %v", err, ErrInvalidTOTPCode)
+	}
+
+	stored, _ := repo.GetUserByID(ctx, user.ID)
+	for _, code := range codes[2:] {
+		if ok, err := s.useRecoveryCode(ctx, stored, code); !ok || err != nil {
+			t.Fatalf("useRecoveryCode(%s) = %v, %v", code, ok, err)
+		}
+		// the same snapshot of the user lose the race against the first use
+		if ok, err := s.useRecoveryCode(ctx, stored, codes[len(codes)-1]); ok || err != nil {
+			t.Fatalf("useRecoveryCode(stale codes) = %v, %v, want false", ok, err)
+		}
+		stored, _ = repo.GetUserByID(ctx, user.ID)
+	}
+	if stored.RecoveryCodes != "[]" {
+		t.Errorf("recovery codes = %s, want every code used", stored.RecoveryCodes)
+	}
+}
+
+func TestLoginTOTPBoundToClient(t *testing.T) {
+	ctx := context.Background()
+	s, repo := newTestService(t)
+	_, hashed, err := newRecoveryCodes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	enabledAt := time.Now()
+	user := repo.addUser(User{
+		Email:         "ana@example.com",
+		Role:          RoleCustomer,
+		TOTPSecret:    "JBSWY3DPEHPK3PXP",
+		TOTPEnabledAt: &enabledAt,
+		RecoveryCodes: hashed,
+	})
+
+	tests := []struct {
+		name     string
+		grant    client.GrantType
+		clientID string
+		want     error
+	}{
+		{"other client", client.GrantPassword, "partner", ErrInvalidPreAuth},
+		{"unknown client", client.GrantPassword, "unknown", client.ErrUnknownClient},
+		{"grant not allowed anymore", client.GrantFirebase, "web", client.ErrGrantNotAllowed},
+		{"same client", client.GrantPassword, "web", ErrInvalidTOTPCode},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			pending, err := s.completeLogin(ctx, user, server.ClientInfo{ClientID: "web"}, testApps["web"], tt.grant)
+			if err != nil {
+				t.Fatal(err)
+			}
+			_, err = s.LoginTOTP(ctx, pending.PreAuthToken, "bbbbb-bbbbb", server.ClientInfo{ClientID: tt.clientID})
+			if err != tt.want {
+				t.Errorf("LoginTOTP() error = %v, want %v", err, tt.want)
+			}
+		})
+	}
+}
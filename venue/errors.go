@@ -0,0 +1,30 @@
+package venue
+
+import (
+	"github.com/booking-man-be/lib/logger"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	ErrInvalidName         = status.Error(codes.InvalidArgument, "venue name must be 1-100 characters")
+	ErrInvalidAddress      = status.Error(codes.InvalidArgument, "address line, city and a 2 letter country code are required")
+	ErrInvalidLocation     = status.Error(codes.InvalidArgument, "latitude must be between -90 and 90 and longitude between -180 and 180")
+	ErrInvalidTimezone     = status.Error(codes.InvalidArgument, "timezone must be an IANA time zone such as Asia/Jakarta")
+	ErrInvalidContactEmail = status.Error(codes.InvalidArgument, "contact email is not a valid email address")
+	ErrInvalidContactPhone = status.Error(codes.InvalidArgument, "contact phone must be 6-20 digits, optionally prefixed with +")
+	ErrInvalidWebsite      = status.Error(codes.InvalidArgument, "website must be an absolute http or https url")
+	ErrInvalidOpeningHours = status.Error(codes.InvalidArgument, "opening hours must be non overlapping HH:MM intervals on weekday 0 (Sunday) to 6")
+	ErrVenueNotFound       = status.Error(codes.NotFound, "venue not found")
+	ErrVenueArchived       = status.Error(codes.FailedPrecondition, "venue is archived")
+	ErrNotVenueManager     = status.Error(codes.PermissionDenied, "you do not manage this venue")
+	ErrInvalidStaffUser    = status.Error(codes.InvalidArgument, "user_id of the staff is required")
+	ErrStaffNotFound       = status.Error(codes.NotFound, "user is not staff of this venue")
+	ErrInternal            = status.Error(codes.Internal, "internal server error")
+)
+
+// internalError logs the underlying error and hides it from the caller
+func internalError(err error) error {
+	logger.Errorf("[venue] %v", err)
+	return ErrInternal
+}
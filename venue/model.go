@@ -0,0 +1,128 @@
+package venue
+
+import (
+	"fmt"
+	"time"
+)
+
+// minutesPerDay is the upper bound of CloseMinute, closing at midnight
+const minutesPerDay = 24 * 60
+
+type Venue struct {
+	ID int `gorm:"primary_key"`
+	// OwnerID is the user managing the venue
+	OwnerID     int    `gorm:"not null;index"`
+	Name        string `gorm:"type:varchar(100);not null"`
+	Description string `gorm:"type:text"`
+
+	Address  Address  `gorm:"embedded"`
+	Location GeoPoint `gorm:"embedded"`
+	// Timezone is IANA time zone in which opening hours and bookings are evaluated
+	Timezone string  `gorm:"type:varchar(64);not null"`
+	Contact  Contact `gorm:"embedded;embeddedPrefix:contact_"`
+
+	OpeningHours []OpeningHour `gorm:"foreignKey:VenueID"`
+
+	CreatedAt time.Time `gorm:"not null"`
+	UpdatedAt time.Time `gorm:"not null"`
+	// ArchivedAt hide the venue from listing, it is kept for existing bookings
+	ArchivedAt *time.Time `gorm:"index"`
+}
+
+type Address struct {
+	AddressLine string `gorm:"type:varchar(255);not null;default:''"`
+	City        string `gorm:"type:varchar(100);not null;default:'';index"`
+	Region      string `gorm:"type:varchar(100);not null;default:''"`
+	PostalCode  string `gorm:"type:varchar(20);not null;default:''"`
+	// Country is ISO 3166-1 alpha-2 code
+	Country string `gorm:"type:char(2);not null;default:''"`
+}
+
+type GeoPoint struct {
+	Latitude  float64 `gorm:"not null;default:0"`
+	Longitude float64 `gorm:"not null;default:0"`
+}
+
+type Contact struct {
+	Email   string `gorm:"type:varchar(255);not null;default:''"`
+	Phone   string `gorm:"type:varchar(30);not null;default:''"`
+	Website string `gorm:"type:varchar(512);not null;default:''"`
+}
+
+// WeeklyHours is an opening interval repeated every week in the venue time zone,
+// a day can have several intervals e.g. closed for lunch
+type WeeklyHours struct {
+	Weekday time.Weekday `gorm:"not null"`
+	// OpenMinute and CloseMinute are minutes since midnight, CloseMinute 1440 close at midnight
+	OpenMinute  int `gorm:"not null"`
+	CloseMinute int `gorm:"not null"`
+}
+
+// OpeningHour is weekly opening interval of the venue
+type OpeningHour struct {
+	ID          int `gorm:"primary_key"`
+	VenueID     int `gorm:"not null;index"`
+	WeeklyHours `gorm:"embedded"`
+}
+
+func (OpeningHour) TableName() string {
+	return "venue_opening_hour"
+}
+
+// Staff is a user working at the venue, it manage the bookings of the venue
+// as long as the user keep the booking:manage permission
+type Staff struct {
+	VenueID   int       `gorm:"primary_key;autoIncrement:false"`
+	UserID    int       `gorm:"primary_key;autoIncrement:false;index"`
+	CreatedAt time.Time `gorm:"not null"`
+}
+
+func (Staff) TableName() string {
+	return "venue_staff"
+}
+
+// UpdateVenue is partial update of the venue, nil field is left untouched
+// and OpeningHours replace every opening hour when it is set
+type UpdateVenue struct {
+	Name         *string
+	Description  *string
+	Address      *Address
+	Location     *GeoPoint
+	Timezone     *string
+	Contact      *Contact
+	OpeningHours *[]OpeningHour
+}
+
+// ListVenues filter venues which are not archived, zero value field is not filtered
+type ListVenues struct {
+	OwnerID int
+	City    string
+	// Query match part of the venue name
+	Query    string
+	Page     int
+	PageSize int
+}
+
+// ParseClock parse HH:MM into minutes since midnight, 24:00 is accepted as end of day
+func ParseClock(s string) (int, error) {
+	if len(s) != 5 || s[2] != ':' {
+		return 0, ErrInvalidOpeningHours
+	}
+	for _, i := range []int{0, 1, 3, 4} {
+		if s[i] < '0' || s[i] > '9' {
+			return 0, ErrInvalidOpeningHours
+		}
+	}
+	hour := int(s[0]-'0')*10 + int(s[1]-'0')
+	minute := int(s[3]-'0')*10 + int(s[4]-'0')
+	m := hour*60 + minute
+	if minute > 59 || m > minutesPerDay {
+		return 0, ErrInvalidOpeningHours
+	}
+	return m, nil
+}
+
+// FormatClock format minutes since midnight as HH:MM
+func FormatClock(m int) string {
+	return fmt.Sprintf("%02d:%02d", m/60, m%60)
+}
@@ -0,0 +1,187 @@
+package venue
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/gomodule/redigo/redis"
+	"gorm.io/gorm"
+	"gorm.io/gorm/clause"
+)
+
+// redis keys
+const (
+	// keyScheduleVersion is incremented on every change of the opening hours, exceptions
+	// or resources of the venue, it is part of availability cache key so stale entries are never read
+	keyScheduleVersion = "venue:schedule_version:%d"
+)
+
+type repository struct {
+	db        *gorm.DB
+	redisPool *redis.Pool
+}
+
+type Repository interface {
+	CreateVenue(ctx context.Context, venue *Venue) error
+	GetVenue(ctx context.Context, id int) (Venue, error)
+	UpdateVenue(ctx context.Context, id int, fields map[string]interface{}, openingHours *[]OpeningHour) error
+	ListVenues(ctx context.Context, filter ListVenues) ([]Venue, int64, error)
+	ArchiveVenue(ctx context.Context, id int, at time.Time) (bool, error)
+
+	AddStaff(ctx context.Context, staff *Staff) error
+	RemoveStaff(ctx context.Context, venueID, userID int) (bool, error)
+	ListStaff(ctx context.Context, venueID int) ([]Staff, error)
+	IsStaff(ctx context.Context, venueID, userID int) (bool, error)
+
+	GetScheduleVersion(ctx context.Context, venueID int) (int64, error)
+	IncrScheduleVersion(ctx context.Context, venueID int) error
+}
+
+func NewRepository(db *gorm.DB, redis *redis.Pool) Repository {
+	return &repository{
+		db:        db,
+		redisPool: redis,
+	}
+}
+
+// CreateVenue insert the venue together with its opening hours
+func (r *repository) CreateVenue(ctx context.Context, venue *Venue) error {
+	return r.db.WithContext(ctx).Create(venue).Error
+}
+
+// GetVenue return gorm.ErrRecordNotFound if the venue does not exist
+func (r *repository) GetVenue(ctx context.Context, id int) (Venue, error) {
+	var venue Venue
+	err := r.db.WithContext(ctx).
+		Preload("OpeningHours", orderOpeningHours).
+		Where("id = ?", id).
+		First(&venue).Error
+	return venue, err
+}
+
+// UpdateVenue save the changed fields, openingHours replace the existing ones when it is not nil
+func (r *repository) UpdateVenue(ctx context.Context, id int, fields map[string]interface{}, openingHours *[]OpeningHour) error {
+	return r.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if len(fields) > 0 {
+			if err := tx.Model(&Venue{}).Where("id = ?", id).Updates(fields).Error; err != nil {
+				return err
+			}
+		}
+		if openingHours == nil {
+			return nil
+		}
+
+		if err := tx.Where("venue_id = ?", id).Delete(&OpeningHour{}).Error; err != nil {
+			return err
+		}
+		if len(*openingHours) == 0 {
+			return nil
+		}
+		for i := range *openingHours {
+			(*openingHours)[i].VenueID = id
+		}
+		return tx.Create(openingHours).Error
+	})
+}
+
+// ListVenues return one page of venues which are not archived, ordered by ID, and the total count
+func (r *repository) ListVenues(ctx context.Context, filter ListVenues) ([]Venue, int64, error) {
+	query := r.db.WithContext(ctx).Model(&Venue{}).Where("archived_at IS NULL")
+	if filter.OwnerID != 0 {
+		query = query.Where("owner_id = ?", filter.OwnerID)
+	}
+	if filter.City != "" {
+		query = query.Where("city = ?", filter.City)
+	}
+	if filter.Query != "" {
+		query = query.Where("name LIKE ?", "%"+escapeLike(filter.Query)+"%")
+	}
+
+	var total int64
+	if err := query.Count(&total).Error; err != nil {
+		return nil, 0, err
+	}
+
+	var venues []Venue
+	err := query.
+		Preload("OpeningHours", orderOpeningHours).
+		Order("id").
+		Offset((filter.Page - 1) * filter.PageSize).
+		Limit(filter.PageSize).
+		Find(&venues).Error
+	return venues, total, err
+}
+
+// ArchiveVenue return false if the venue does not exist or it is already archived
+func (r *repository) ArchiveVenue(ctx context.Context, id int, at time.Time) (bool, error) {
+	result := r.db.WithContext(ctx).Model(&Venue{}).
+		Where("id = ? AND archived_at IS NULL", id).
+		Update("archived_at", at)
+	return result.RowsAffected > 0, result.Error
+}
+
+// AddStaff insert the staff, adding an existing staff again keep it unchanged
+func (r *repository) AddStaff(ctx context.Context, staff *Staff) error {
+	return r.db.WithContext(ctx).Clauses(clause.OnConflict{DoNothing: true}).Create(staff).Error
+}
+
+// RemoveStaff return false if the user is not staff of the venue
+func (r *repository) RemoveStaff(ctx context.Context, venueID, userID int) (bool, error) {
+	result := r.db.WithContext(ctx).
+		Where("venue_id = ? AND user_id = ?", venueID, userID).
+		Delete(&Staff{})
+	return result.RowsAffected > 0, result.Error
+}
+
+func (r *repository) ListStaff(ctx context.Context, venueID int) ([]Staff, error) {
+	var staff []Staff
+	err := r.db.WithContext(ctx).
+		Where("venue_id = ?", venueID).
+		Order("user_id").
+		Find(&staff).Error
+	return staff, err
+}
+
+func (r *repository) IsStaff(ctx context.Context, venueID, userID int) (bool, error) {
+	var count int64
+	err := r.db.WithContext(ctx).Model(&Staff{}).
+		Where("venue_id = ? AND user_id = ?", venueID, userID).
+		Count(&count).Error
+	return count > 0, err
+}
+
+// GetScheduleVersion return the version of the venue schedule, zero when it was never changed
+func (r *repository) GetScheduleVersion(ctx context.Context, venueID int) (int64, error) {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return 0, err
+	}
+	defer conn.Close()
+
+	version, err := redis.Int64(conn.Do("GET", fmt.Sprintf(keyScheduleVersion, venueID)))
+	if err == redis.ErrNil {
+		return 0, nil
+	}
+	return version, err
+}
+
+func (r *repository) IncrScheduleVersion(ctx context.Context, venueID int) error {
+	conn, err := r.redisPool.GetContext(ctx)
+	if err != nil {
+		return err
+	}
+	defer conn.Close()
+
+	_, err = conn.Do("INCR", fmt.Sprintf(keyScheduleVersion, venueID))
+	return err
+}
+
+func orderOpeningHours(db *gorm.DB) *gorm.DB {
+	return db.Order("weekday, open_minute")
+}
+
+// escapeLike escape the wildcard characters of LIKE pattern
+func escapeLike(s string) string {
+	return likeEscaper.Replace(s)
+}
@@ -0,0 +1,408 @@
+package venue
+
+import (
+	"context"
+	"errors"
+	"net/mail"
+	"net/url"
+	"regexp"
+	"sort"
+	"strings"
+	"time"
+	"unicode/utf8"
+
+	"github.com/booking-man-be/lib/logger"
+	"github.com/booking-man-be/lib/server"
+	"gorm.io/gorm"
+)
+
+const (
+	maxNameLength   = 100
+	defaultPageSize = 20
+	maxPageSize     = 100
+)
+
+var (
+	phoneRegex   = regexp.MustCompile(`^\+?[0-9]{6,20}$`)
+	countryRegex = regexp.MustCompile(`^[A-Z]{2}$`)
+	likeEscaper  = strings.NewReplacer(`\`, `\\`, `%`, `\%`, `_`, `\_`)
+)
+
+type service struct {
+	repo Repository
+}
+
+type Service interface {
+	CreateVenue(ctx context.Context, actor server.AuthInfo, venue Venue) (Venue, error)
+	GetVenue(ctx context.Context, id int) (Venue, error)
+	UpdateVenue(ctx context.Context, actor server.AuthInfo, id int, req UpdateVenue) (Venue, error)
+	ListVenues(ctx context.Context, filter ListVenues) ([]Venue, int64, error)
+	ArchiveVenue(ctx context.Context, actor server.AuthInfo, id int) error
+	// GetManagedVenue return the venue if the actor is allowed to manage it
+	GetManagedVenue(ctx context.Context, actor server.AuthInfo, id int) (Venue, error)
+	// GetStaffedVenue return the venue if the actor is allowed to manage its bookings
+	GetStaffedVenue(ctx context.Context, actor server.AuthInfo, id int) (Venue, error)
+
+	// the staff of the venue is managed by the venue manager
+	AddStaff(ctx context.Context, actor server.AuthInfo, venueID, userID int) (Staff, error)
+	RemoveStaff(ctx context.Context, actor server.AuthInfo, venueID, userID int) error
+	ListStaff(ctx context.Context, actor server.AuthInfo, venueID int) ([]Staff, error)
+
+	// ScheduleVersion return a counter which change whenever the opening hours,
+	// exceptions or resources of the venue change
+	ScheduleVersion(ctx context.Context, venueID int) (int64, error)
+	// ScheduleChanged invalidate the cached availability of the venue
+	ScheduleChanged(ctx context.Context, venueID int)
+}
+
+func NewService(repo Repository) Service {
+	return &service{
+		repo: repo,
+	}
+}
+
+// CreateVenue validate and store new venue owned by the actor
+func (s *service) CreateVenue(ctx context.Context, actor server.AuthInfo, venue Venue) (Venue, error) {
+	venue.Name = strings.TrimSpace(venue.Name)
+	if err := validateName(venue.Name); err != nil {
+		return Venue{}, err
+	}
+	venue.Address = normalizeAddress(venue.Address)
+	if err := validateAddress(venue.Address); err != nil {
+		return Venue{}, err
+	}
+	if err := validateLocation(venue.Location); err != nil {
+		return Venue{}, err
+	}
+	if err := validateTimezone(venue.Timezone); err != nil {
+		return Venue{}, err
+	}
+	venue.Contact = normalizeContact(venue.Contact)
+	if err := validateContact(venue.Contact); err != nil {
+		return Venue{}, err
+	}
+	if err := validateOpeningHours(venue.OpeningHours); err != nil {
+		return Venue{}, err
+	}
+
+	venue.ID = 0
+	venue.OwnerID = actor.UserID
+	venue.ArchivedAt = nil
+	for i := range venue.OpeningHours {
+		venue.OpeningHours[i].ID = 0
+	}
+	if err := s.repo.CreateVenue(ctx, &venue); err != nil {
+		return Venue{}, internalError(err)
+	}
+	return s.GetVenue(ctx, venue.ID)
+}
+
+// GetVenue return the venue including archived one
+func (s *service) GetVenue(ctx context.Context, id int) (Venue, error) {
+	venue, err := s.repo.GetVenue(ctx, id)
+	if errors.Is(err, gorm.ErrRecordNotFound) {
+		return Venue{}, ErrVenueNotFound
+	}
+	if err != nil {
+		return Venue{}, internalError(err)
+	}
+	return venue, nil
+}
+
+// GetManagedVenue return the venue if the actor own it or is platform admin
+func (s *service) GetManagedVenue(ctx context.Context, actor server.AuthInfo, id int) (Venue, error) {
+	venue, err := s.GetVenue(ctx, id)
+	if err != nil {
+		return Venue{}, err
+	}
+	if venue.OwnerID != actor.UserID && !actor.IsPlatformAdmin() {
+		return Venue{}, ErrNotVenueManager
+	}
+	return venue, nil
+}
+
+// GetStaffedVenue return the venue if the actor manage it, or work at the venue
+// and has the booking:manage permission
+func (s *service) GetStaffedVenue(ctx context.Context, actor server.AuthInfo, id int) (Venue, error) {
+	venue, err := s.GetVenue(ctx, id)
+	if err != nil {
+		return Venue{}, err
+	}
+	if venue.OwnerID == actor.UserID || actor.IsPlatformAdmin() {
+		return venue, nil
+	}
+	if !actor.HasPermission(server.PermissionBookingManage) {
+		return Venue{}, ErrNotVenueManager
+	}
+	staff, err := s.repo.IsStaff(ctx, id, actor.UserID)
+	if err != nil {
+		return Venue{}, internalError(err)
+	}
+	if !staff {
+		return Venue{}, ErrNotVenueManager
+	}
+	return venue, nil
+}
+
+// AddStaff let the user manage the bookings of the venue, the user still need
+// the booking:manage permission of its role
+func (s *service) AddStaff(ctx context.Context, actor server.AuthInfo, venueID, userID int) (Staff, error) {
+	if userID <= 0 {
+		return Staff{}, ErrInvalidStaffUser
+	}
+	venue, err := s.GetManagedVenue(ctx, actor, venueID)
+	if err != nil {
+		return Staff{}, err
+	}
+	if venue.ArchivedAt != nil {
+		return Staff{}, ErrVenueArchived
+	}
+	staff := Staff{VenueID: venueID, UserID: userID}
+	if err := s.repo.AddStaff(ctx, &staff); err != nil {
+		return Staff{}, internalError(err)
+	}
+	return staff, nil
+}
+
+func (s *service) RemoveStaff(ctx context.Context, actor server.AuthInfo, venueID, userID int) error {
+	if _, err := s.GetManagedVenue(ctx, actor, venueID); err != nil {
+		return err
+	}
+	removed, err := s.repo.RemoveStaff(ctx, venueID, userID)
+	if err != nil {
+		return internalError(err)
+	}
+	if !removed {
+		return ErrStaffNotFound
+	}
+	return nil
+}
+
+func (s *service) ListStaff(ctx context.Context, actor server.AuthInfo, venueID int) ([]Staff, error) {
+	if _, err := s.GetManagedVenue(ctx, actor, venueID); err != nil {
+		return nil, err
+	}
+	staff, err := s.repo.ListStaff(ctx, venueID)
+	if err != nil {
+		return nil, internalError(err)
+	}
+	return staff, nil
+}
+
+// UpdateVenue validate and save the given fields, archived venue can not be changed
+func (s *service) UpdateVenue(ctx context.Context, actor server.AuthInfo, id int, req UpdateVenue) (Venue, error) {
+	venue, err := s.GetManagedVenue(ctx, actor, id)
+	if err != nil {
+		return Venue{}, err
+	}
+	if venue.ArchivedAt != nil {
+		return Venue{}, ErrVenueArchived
+	}
+
+	fields := map[string]interface{}{}
+	if req.Name != nil {
+		name := strings.TrimSpace(*req.Name)
+		if err := validateName(name); err != nil {
+			return Venue{}, err
+		}
+		fields["name"] = name
+	}
+	if req.Description != nil {
+		fields["description"] = strings.TrimSpace(*req.Description)
+	}
+	if req.Address != nil {
+		address := normalizeAddress(*req.Address)
+		if err := validateAddress(address); err != nil {
+			return Venue{}, err
+		}
+		fields["address_line"] = address.AddressLine
+		fields["city"] = address.City
+		fields["region"] = address.Region
+		fields["postal_code"] = address.PostalCode
+		fields["country"] = address.Country
+	}
+	if req.Location != nil {
+		if err := validateLocation(*req.Location); err != nil {
+			return Venue{}, err
+		}
+		fields["latitude"] = req.Location.Latitude
+		fields["longitude"] = req.Location.Longitude
+	}
+	if req.Timezone != nil {
+		if err := validateTimezone(*req.Timezone); err != nil {
+			return Venue{}, err
+		}
+		fields["timezone"] = *req.Timezone
+	}
+	if req.Contact != nil {
+		contact := normalizeContact(*req.Contact)
+		if err := validateContact(contact); err != nil {
+			return Venue{}, err
+		}
+		fields["contact_email"] = contact.Email
+		fields["contact_phone"] = contact.Phone
+		fields["contact_website"] = contact.Website
+	}
+	if req.OpeningHours != nil {
+		if err := validateOpeningHours(*req.OpeningHours); err != nil {
+			return Venue{}, err
+		}
+	}
+
+	if len(fields) > 0 || req.OpeningHours != nil {
+		if err := s.repo.UpdateVenue(ctx, id, fields, req.OpeningHours); err != nil {
+			return Venue{}, internalError(err)
+		}
+		// the opening hours and the timezone shape the schedule
+		s.ScheduleChanged(ctx, id)
+	}
+	return s.GetVenue(ctx, id)
+}
+
+// ListVenues return one page of venues which are not archived and the total count
+func (s *service) ListVenues(ctx context.Context, filter ListVenues) ([]Venue, int64, error) {
+	if filter.Page < 1 {
+		filter.Page = 1
+	}
+	if filter.PageSize < 1 {
+		filter.PageSize = defaultPageSize
+	}
+	if filter.PageSize > maxPageSize {
+		filter.PageSize = maxPageSize
+	}
+	filter.City = strings.TrimSpace(filter.City)
+	filter.Query = strings.TrimSpace(filter.Query)
+
+	venues, total, err := s.repo.ListVenues(ctx, filter)
+	if err != nil {
+		return nil, 0, internalError(err)
+	}
+	return venues, total, nil
+}
+
+// ArchiveVenue hide the venue from listing, it can not be undone
+func (s *service) ArchiveVenue(ctx context.Context, actor server.AuthInfo, id int) error {
+	if _, err := s.GetManagedVenue(ctx, actor, id); err != nil {
+		return err
+	}
+	archived, err := s.repo.ArchiveVenue(ctx, id, time.Now())
+	if err != nil {
+		return internalError(err)
+	}
+	if !archived {
+		return ErrVenueArchived
+	}
+	return nil
+}
+
+func (s *service) ScheduleVersion(ctx context.Context, venueID int) (int64, error) {
+	version, err := s.repo.GetScheduleVersion(ctx, venueID)
+	if err != nil {
+		return 0, internalError(err)
+	}
+	return version, nil
+}
+
+func (s *service) ScheduleChanged(ctx context.Context, venueID int) {
+	if err := s.repo.IncrScheduleVersion(ctx, venueID); err != nil {
+		logger.Errorf("[venue] failed to bump schedule version of venue %d, %v", venueID, err)
+	}
+}
+
+func validateName(name string) error {
+	if name == "" || utf8.RuneCountInString(name) > maxNameLength {
+		return ErrInvalidName
+	}
+	return nil
+}
+
+func normalizeAddress(a Address) Address {
+	return Address{
+		AddressLine: strings.TrimSpace(a.AddressLine),
+		City:        strings.TrimSpace(a.City),
+		Region:      strings.TrimSpace(a.Region),
+		PostalCode:  strings.TrimSpace(a.PostalCode),
+		Country:     strings.ToUpper(strings.TrimSpace(a.Country)),
+	}
+}
+
+func validateAddress(a Address) error {
+	if a.AddressLine == "" || a.City == "" || !countryRegex.MatchString(a.Country) {
+		return ErrInvalidAddress
+	}
+	return nil
+}
+
+func validateLocation(p GeoPoint) error {
+	if p.Latitude < -90 || p.Latitude > 90 || p.Longitude < -180 || p.Longitude > 180 {
+		return ErrInvalidLocation
+	}
+	return nil
+}
+
+func validateTimezone(tz string) error {
+	if _, err := time.LoadLocation(tz); err != nil || tz == "" || tz == "Local" {
+		return ErrInvalidTimezone
+	}
+	return nil
+}
+
+func normalizeContact(c Contact) Contact {
+	return Contact{
+		Email:   strings.ToLower(strings.TrimSpace(c.Email)),
+		Phone:   strings.NewReplacer(" ", "", "-", "").Replace(c.Phone),
+		Website: strings.TrimSpace(c.Website),
+	}
+}
+
+func validateContact(c Contact) error {
+	if c.Email != "" {
+		if addr, err := mail.ParseAddress(c.Email); err != nil || addr.Address != c.Email {
+			return ErrInvalidContactEmail
+		}
+	}
+	if c.Phone != "" && !phoneRegex.MatchString(c.Phone) {
+		return ErrInvalidContactPhone
+	}
+	if c.Website != "" && !isHTTPURL(c.Website) {
+		return ErrInvalidWebsite
+	}
+	return nil
+}
+
+func isHTTPURL(raw string) bool {
+	u, err := url.Parse(raw)
+	return err == nil && (u.Scheme == "http" || u.Scheme == "https") && u.Host != ""
+}
+
+func validateOpeningHours(hours []OpeningHour) error {
+	weekly := make([]WeeklyHours, 0, len(hours))
+	for _, h := range hours {
+		weekly = append(weekly, h.WeeklyHours)
+	}
+	return ValidateWeeklyHours(weekly)
+}
+
+// ValidateWeeklyHours make sure every interval is inside a day and intervals
+// of the same day do not overlap
+func ValidateWeeklyHours(hours []WeeklyHours) error {
+	sorted := make([]WeeklyHours, len(hours))
+	copy(sorted, hours)
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].Weekday != sorted[j].Weekday {
+			return sorted[i].Weekday < sorted[j].Weekday
+		}
+		return sorted[i].OpenMinute < sorted[j].OpenMinute
+	})
+
+	for i, h := range sorted {
+		if h.Weekday < time.Sunday || h.Weekday > time.Saturday ||
+			h.OpenMinute < 0 || h.OpenMinute >= h.CloseMinute || h.CloseMinute > minutesPerDay {
+			return ErrInvalidOpeningHours
+		}
+		if i > 0 && sorted[i-1].Weekday == h.Weekday && sorted[i-1].CloseMinute > h.OpenMinute {
+			return ErrInvalidOpeningHours
+		}
+	}
+	return nil
+}